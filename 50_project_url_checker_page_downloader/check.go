@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"master_go_programming/50_project_url_checker_page_downloader/content"
+	"master_go_programming/50_project_url_checker_page_downloader/retry"
+)
+
+// checker holds what every check needs
+type checker struct {
+	client *http.Client // its Timeout bounds each attempt
+	policy retry.Policy
+	store  *content.Store // nil = do not save bodies
+}
+
+// result is what checking one URL found out
+type result struct {
+	url        string
+	statusCode int           // 0 when no response arrived
+	attempts   int           // requests sent, retries included
+	elapsed    time.Duration // time until the body was read or the request failed
+	file       string        // where the body was saved, "" if it was not
+	unchanged  bool          // the server answered 304: the saved copy is still current
+	resumedAt  int64         // the download continued an interrupted one from this byte
+	err        error         // why the request or saving failed
+	skipped    bool          // the run was stopped before this URL was checked
+}
+
+// Up reports whether the server answered with a 2xx or 3xx status.
+// A 4xx or 5xx answer counts as DOWN, just like no answer at all.
+func (r result) Up() bool {
+	return r.err == nil && r.statusCode >= 200 && r.statusCode < 400
+}
+
+// checkAndSaveBody checks if a given URL is reachable.
+// If the response is 200 (OK) and c.store is set, it saves the response body in the store.
+// With a store, the request is conditional on the saved copy, so an unchanged page is not
+// downloaded again, and an interrupted download continues where it stopped (see content.Store.Fetch).
+// Temporary failures are retried according to c.policy.
+// When ctx is cancelled, the request, the wait before a retry or the body download stops
+// and nothing is written under the final name.
+func (c *checker) checkAndSaveBody(ctx context.Context, url string) (res result) {
+	res.url = url
+	// res is a named result, so the deferred function can still set elapsed after each return
+	start := time.Now()
+	defer func() { res.elapsed = time.Since(start) }()
+
+	if c.store != nil {
+		// The store sends the request itself, so it can make it conditional or ranged
+		f, err := c.store.Fetch(ctx, c.client, c.policy, url)
+		res.statusCode, res.attempts, res.err = f.Status, f.Attempts, err
+		res.unchanged, res.resumedAt = f.Unchanged, f.ResumedAt
+		if f.Saved || f.Unchanged {
+			res.file = filepath.Join(c.store.Dir(), filepath.FromSlash(f.Entry.File))
+		}
+		return res
+	}
+
+	// Attempt to send GET request to the URL
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		res.err = err
+		return res
+	}
+	resp, attempts, err := c.policy.Do(ctx, c.client, req)
+	res.attempts = attempts
+	if err != nil {
+		res.err = err
+		return res
+	}
+	defer resp.Body.Close()
+	res.statusCode = resp.StatusCode
+
+	// Nothing to save: drain the body so the connection can be reused
+	_, res.err = io.Copy(io.Discard, resp.Body)
+	return res
+}
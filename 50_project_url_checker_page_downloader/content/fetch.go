@@ -0,0 +1,194 @@
+package content
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"master_go_programming/50_project_url_checker_page_downloader/retry"
+)
+
+// FetchResult says what Store.Fetch did
+type FetchResult struct {
+	Status    int   // Status of the last response: 200, 206 (resumed), 304 (unchanged), or an error status; 0 without a response
+	Attempts  int   // Requests sent, retries and resumed transfers included
+	Unchanged bool  // The server answered 304 Not Modified: the stored copy is still current
+	Saved     bool  // A new body was stored
+	ResumedAt int64 // Where the stored body was continued from; 0 if it was downloaded from the start
+	Entry     Entry // The stored entry, when Saved or Unchanged
+}
+
+// Fetch downloads url into the store, sparing the bandwidth that a plain GET would waste:
+//
+//   - If the store has a copy with an ETag or Last-Modified, the request carries
+//     If-None-Match / If-Modified-Since, and a 304 Not Modified answer just marks the copy as checked.
+//   - The body goes to a .part file first. If the transfer breaks off and the server accepts
+//     ranges (Accept-Ranges: bytes) and sent a validator, the .part file is kept, and the next
+//     attempt, in this call or a later run, asks only for the rest with Range and If-Range.
+//     If the page changed in between, the server sends it whole again and the download restarts.
+//
+// Only 200 and 206 answers are stored; any other status is returned in FetchResult.Status.
+// Temporary failures are retried according to policy, and interrupted transfers are resumed
+// up to policy.MaxAttempts times as well.
+func (s *Store) Fetch(ctx context.Context, client *http.Client, policy retry.Policy, url string) (FetchResult, error) {
+	var res FetchResult
+	for try := 0; ; try++ {
+		resumable, err := s.fetchOnce(ctx, client, policy, url, &res)
+		if err == nil || !resumable || try+1 >= max(policy.MaxAttempts, 1) || ctx.Err() != nil {
+			return res, err
+		}
+
+		timer := time.NewTimer(policy.Backoff(try))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return res, err
+		}
+	}
+}
+
+// fetchOnce sends one (possibly conditional or ranged) request and stores the answer.
+// resumable reports whether a failed transfer left a .part file to continue from.
+func (s *Store) fetchOnce(ctx context.Context, client *http.Client, policy retry.Policy, url string, res *FetchResult) (resumable bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return false, err
+	}
+
+	// Ask whether the stored copy is still current
+	if e, ok := s.Entry(url); ok && s.exists(e.File) {
+		if e.ETag != "" {
+			req.Header.Set("If-None-Match", e.ETag)
+		}
+		if e.LastModified != "" {
+			req.Header.Set("If-Modified-Since", e.LastModified)
+		}
+	}
+
+	// Ask only for the missing rest of an interrupted download, as long as the page has not changed
+	p, havePart := s.loadPartial(url)
+	if v := ifRange(p); havePart && p.size > 0 && v != "" {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", p.size))
+		req.Header.Set("If-Range", v)
+	}
+
+	resp, attempts, err := policy.Do(ctx, client, req)
+	res.Attempts += attempts
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	res.Status = resp.StatusCode
+	res.ResumedAt = 0
+
+	var offset int64
+	switch resp.StatusCode {
+	case http.StatusNotModified:
+		io.Copy(io.Discard, resp.Body)
+		res.Entry, res.Unchanged = s.touch(url)
+		return false, nil
+
+	case http.StatusPartialContent:
+		start, ok := contentRangeStart(resp.Header.Get("Content-Range"))
+		if !havePart || !ok || start != p.size {
+			// Not the range we asked for: start over without the .part file
+			io.Copy(io.Discard, resp.Body)
+			s.removePartial(url)
+			return true, fmt.Errorf("content: unexpected Content-Range %q", resp.Header.Get("Content-Range"))
+		}
+		offset = p.size
+		res.ResumedAt = offset
+
+	case http.StatusOK:
+		// A new download, or the page changed since the .part file was started
+		p = partial{
+			URL:          url,
+			Status:       resp.StatusCode,
+			ContentType:  resp.Header.Get("Content-Type"),
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+		}
+
+	case http.StatusRequestedRangeNotSatisfiable:
+		// The .part file is as long as the page or longer, so it cannot be trusted
+		io.Copy(io.Discard, resp.Body)
+		s.removePartial(url)
+		return true, fmt.Errorf("content: %s", resp.Status)
+
+	default:
+		io.Copy(io.Discard, resp.Body)
+		return false, nil
+	}
+
+	resumable = ifRange(p) != "" && (resp.StatusCode == http.StatusPartialContent || resp.Header.Get("Accept-Ranges") == "bytes")
+	if resumable {
+		// Written before the body, so even a crash leaves everything needed to resume
+		if err := s.savePartialMeta(p); err != nil {
+			return false, err
+		}
+	}
+
+	if _, err := s.writePartial(url, offset, resp.Body); err != nil {
+		if !resumable {
+			s.removePartial(url)
+		}
+		return resumable, fmt.Errorf("downloading body: %w", err)
+	}
+
+	res.Entry, err = s.commitPartial(p)
+	if err != nil {
+		return false, err
+	}
+	res.Saved = true
+	return false, nil
+}
+
+// touch records that the server confirmed the stored copy of url is current
+func (s *Store) touch(url string) (Entry, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.entries[url]
+	if !ok {
+		return e, false
+	}
+	e.CheckedAt = time.Now()
+	s.entries[url] = e
+	s.dirty = true
+	return e, true
+}
+
+// exists reports whether a stored file is still on disk
+func (s *Store) exists(rel string) bool {
+	_, err := os.Stat(filepath.Join(s.dir, filepath.FromSlash(rel)))
+	return err == nil
+}
+
+// ifRange returns the validator to send in If-Range for p: its ETag, unless it is weak
+// (If-Range only accepts strong ETags), otherwise its Last-Modified date; "" if there is none.
+func ifRange(p partial) string {
+	if p.ETag != "" && !strings.HasPrefix(p.ETag, "W/") {
+		return p.ETag
+	}
+	return p.LastModified
+}
+
+// contentRangeStart returns the first byte of a Content-Range header such as "bytes 100-199/200"
+func contentRangeStart(v string) (int64, bool) {
+	rest, ok := strings.CutPrefix(v, "bytes ")
+	if !ok {
+		return 0, false
+	}
+	first, _, ok := strings.Cut(rest, "-")
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.ParseInt(first, 10, 64)
+	return n, err == nil
+}
@@ -0,0 +1,254 @@
+package content
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"master_go_programming/50_project_url_checker_page_downloader/retry"
+)
+
+// pageServer serves one page with an ETag and, through http.ServeContent,
+// answers If-None-Match, Range and If-Range like a real server.
+type pageServer struct {
+	mu      sync.Mutex
+	etag    string
+	body    []byte
+	cutAt   int           // If > 0, the next full response breaks off after this many bytes
+	headers []http.Header // Headers of every request received
+	noRange bool          // Send neither validators nor Accept-Ranges, so nothing can be resumed
+}
+
+func (ps *pageServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ps.mu.Lock()
+	etag, body, cutAt, noRange := ps.etag, ps.body, ps.cutAt, ps.noRange
+	ps.headers = append(ps.headers, r.Header.Clone())
+	if r.Header.Get("Range") == "" {
+		ps.cutAt = 0
+	}
+	ps.mu.Unlock()
+
+	if !noRange {
+		w.Header().Set("ETag", etag)
+	}
+	if cutAt > 0 && r.Header.Get("Range") == "" {
+		if !noRange {
+			w.Header().Set("Accept-Ranges", "bytes")
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		w.WriteHeader(http.StatusOK)
+		w.Write(body[:cutAt])
+		w.(http.Flusher).Flush()
+		// Drops the connection, so the client sees an unexpected EOF
+		panic(http.ErrAbortHandler)
+	}
+	if noRange {
+		w.Write(body)
+		return
+	}
+	http.ServeContent(w, r, "page.bin", time.Time{}, bytes.NewReader(body))
+}
+
+func (ps *pageServer) set(etag string, body []byte, cutAt int) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	ps.etag, ps.body, ps.cutAt = etag, body, cutAt
+}
+
+func (ps *pageServer) lastRequest() http.Header {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	return ps.headers[len(ps.headers)-1]
+}
+
+// fetchPolicy retries without waiting, so the tests stay fast
+func fetchPolicy(attempts int) retry.Policy {
+	p := retry.Default()
+	p.MaxAttempts = attempts
+	p.BaseDelay = 0
+	return p
+}
+
+func pageBody(n int, seed byte) []byte {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = seed + byte(i%251)
+	}
+	return b
+}
+
+// storedBody reads the file the store keeps for url
+func storedBody(t *testing.T, s *Store, url string) []byte {
+	t.Helper()
+	e, ok := s.Entry(url)
+	if !ok {
+		t.Fatalf("no entry for %s", url)
+	}
+	b, err := os.ReadFile(filepath.Join(s.Dir(), filepath.FromSlash(e.File)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+// partialFiles lists what is left in the directory of interrupted downloads
+func partialFiles(t *testing.T, s *Store) []string {
+	t.Helper()
+	files, err := filepath.Glob(filepath.Join(s.Dir(), partialDir, "*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
+
+func TestFetchConditional(t *testing.T) {
+	ps := &pageServer{}
+	ps.set(`"v1"`, pageBody(5000, 0), 0)
+	srv := httptest.NewServer(ps)
+	defer srv.Close()
+	url := srv.URL + "/page.bin"
+	ctx := context.Background()
+
+	dir := t.TempDir()
+	s, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := s.Fetch(ctx, srv.Client(), fetchPolicy(1), url)
+	if err != nil || res.Status != http.StatusOK || !res.Saved || res.Entry.ETag != `"v1"` {
+		t.Fatalf("first Fetch = %+v, %v; want a saved 200 with ETag \"v1\"", res, err)
+	}
+
+	// The copy is current: the server answers 304 and nothing is downloaded
+	res, err = s.Fetch(ctx, srv.Client(), fetchPolicy(1), url)
+	if err != nil || res.Status != http.StatusNotModified || !res.Unchanged || res.Saved || res.Entry.CheckedAt.IsZero() {
+		t.Fatalf("second Fetch = %+v, %v; want an unchanged 304", res, err)
+	}
+
+	// The validators survive in the manifest, so the next run asks conditionally too
+	if err := s.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if s, err = Open(dir); err != nil {
+		t.Fatal(err)
+	}
+	res, err = s.Fetch(ctx, srv.Client(), fetchPolicy(1), url)
+	if err != nil || !res.Unchanged {
+		t.Fatalf("Fetch after reopening = %+v, %v; want an unchanged 304", res, err)
+	}
+
+	// Without the file on disk, a 304 would leave nothing to keep: download it again
+	if err := os.Remove(filepath.Join(dir, filepath.FromSlash(res.Entry.File))); err != nil {
+		t.Fatal(err)
+	}
+	res, err = s.Fetch(ctx, srv.Client(), fetchPolicy(1), url)
+	if err != nil || res.Status != http.StatusOK || !res.Saved {
+		t.Fatalf("Fetch after removing the file = %+v, %v; want a saved 200", res, err)
+	}
+	if !bytes.Equal(storedBody(t, s, url), ps.body) {
+		t.Fatal("stored body differs from the page")
+	}
+}
+
+func TestFetchResume(t *testing.T) {
+	const size, cut = 100_000, 30_000
+	ps := &pageServer{}
+	ps.set(`"v1"`, pageBody(size, 0), cut)
+	srv := httptest.NewServer(ps)
+	defer srv.Close()
+	url := srv.URL + "/page.bin"
+	ctx := context.Background()
+
+	s, err := Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The first transfer breaks off; the retry asks only for the rest
+	res, err := s.Fetch(ctx, srv.Client(), fetchPolicy(3), url)
+	if err != nil || res.Status != http.StatusPartialContent || res.ResumedAt != cut || res.Attempts != 2 || !res.Saved {
+		t.Fatalf("Fetch = %+v, %v; want a 206 resumed at %d after 2 attempts", res, err, cut)
+	}
+	if h := ps.lastRequest(); h.Get("Range") != "bytes=30000-" || h.Get("If-Range") != `"v1"` {
+		t.Errorf("Range = %q, If-Range = %q; want bytes=30000-, \"v1\"", h.Get("Range"), h.Get("If-Range"))
+	}
+	if !bytes.Equal(storedBody(t, s, url), ps.body) {
+		t.Fatal("resumed body differs from the page")
+	}
+	if files := partialFiles(t, s); len(files) != 0 {
+		t.Errorf("partial files left after a complete download: %v", files)
+	}
+
+	// Interrupted again, without a retry this time: the .part file waits for the next call
+	ps.set(`"v1"`, ps.body, cut)
+	res, err = s.Fetch(ctx, srv.Client(), fetchPolicy(1), srv.URL+"/other")
+	if err == nil || res.Saved {
+		t.Fatalf("interrupted Fetch = %+v, %v; want an error", res, err)
+	}
+	if files := partialFiles(t, s); len(files) == 0 {
+		t.Fatal("no partial file kept for a resumable download")
+	}
+
+	// Meanwhile the page changed: If-Range no longer matches, so the server sends all of it
+	ps.set(`"v2"`, pageBody(size, 7), 0)
+	res, err = s.Fetch(ctx, srv.Client(), fetchPolicy(1), srv.URL+"/other")
+	if err != nil || res.Status != http.StatusOK || res.ResumedAt != 0 || res.Entry.ETag != `"v2"` {
+		t.Fatalf("Fetch of the changed page = %+v, %v; want a fresh 200 with ETag \"v2\"", res, err)
+	}
+	if h := ps.lastRequest(); h.Get("Range") != "bytes=30000-" || h.Get("If-Range") != `"v1"` {
+		t.Errorf("Range = %q, If-Range = %q; want bytes=30000-, \"v1\"", h.Get("Range"), h.Get("If-Range"))
+	}
+	if !bytes.Equal(storedBody(t, s, srv.URL+"/other"), ps.body) {
+		t.Fatal("stored body is not the changed page")
+	}
+}
+
+func TestFetchNotResumable(t *testing.T) {
+	ps := &pageServer{noRange: true}
+	ps.set("", pageBody(10_000, 0), 4000)
+	srv := httptest.NewServer(ps)
+	defer srv.Close()
+	url := srv.URL + "/page.bin"
+
+	s, err := Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Nothing to continue from, so the broken transfer is not retried
+	res, err := s.Fetch(context.Background(), srv.Client(), fetchPolicy(3), url)
+	if err == nil || res.Attempts != 1 || res.Saved {
+		t.Fatalf("Fetch = %+v, %v; want one failed attempt", res, err)
+	}
+	if files := partialFiles(t, s); len(files) != 0 {
+		t.Errorf("partial files kept although the server cannot resume: %v", files)
+	}
+	if _, ok := s.Entry(url); ok {
+		t.Error("entry stored for a broken download")
+	}
+}
+
+func TestContentRangeStart(t *testing.T) {
+	tests := []struct {
+		header string
+		want   int64
+		ok     bool
+	}{
+		{"bytes 100-199/200", 100, true},
+		{"bytes 0-99/*", 0, true},
+		{"bytes */200", 0, false},
+		{"items 1-2/3", 0, false},
+		{"", 0, false},
+	}
+	for _, tt := range tests {
+		if got, ok := contentRangeStart(tt.header); got != tt.want || ok != tt.ok {
+			t.Errorf("contentRangeStart(%q) = %d, %v; want %d, %v", tt.header, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package content
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// partialDir holds interrupted downloads inside the store directory.
+// They are named by URL hash, so the next run finds them before knowing the content type.
+const partialDir = ".partial"
+
+// partial describes an interrupted download that can be resumed with a Range request.
+// It is kept as <hash>.json next to the bytes received so far, <hash>.part.
+type partial struct {
+	URL          string `json:"url"`
+	Status       int    `json:"status"`
+	ContentType  string `json:"content_type,omitempty"`
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+
+	size int64 // Bytes in the .part file, read from the file system
+}
+
+// partialPaths returns the names of the data and metadata files of rawURL's partial download
+func (s *Store) partialPaths(rawURL string) (data, meta string, err error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", "", err
+	}
+	base := filepath.Join(s.dir, partialDir, urlHash(u))
+	return base + ".part", base + ".json", nil
+}
+
+// loadPartial returns the partial download of rawURL; ok is false if there is none.
+func (s *Store) loadPartial(rawURL string) (p partial, ok bool) {
+	data, meta, err := s.partialPaths(rawURL)
+	if err != nil {
+		return p, false
+	}
+	b, err := os.ReadFile(meta)
+	if err != nil || json.Unmarshal(b, &p) != nil || p.URL != rawURL {
+		return p, false
+	}
+	info, err := os.Stat(data)
+	if err != nil {
+		return p, false
+	}
+	p.size = info.Size()
+	return p, true
+}
+
+// savePartialMeta writes the metadata of p's partial download
+func (s *Store) savePartialMeta(p partial) error {
+	_, meta, err := s.partialPaths(p.URL)
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(meta, func(w io.Writer) error {
+		_, err := w.Write(b)
+		return err
+	})
+}
+
+// writePartial writes body into rawURL's .part file starting at offset,
+// dropping anything after offset first. It returns the size of the file afterwards.
+// The bytes received before an error stay in the file.
+func (s *Store) writePartial(rawURL string, offset int64, body io.Reader) (int64, error) {
+	data, _, err := s.partialPaths(rawURL)
+	if err != nil {
+		return 0, err
+	}
+	if err := os.MkdirAll(filepath.Dir(data), 0775); err != nil {
+		return 0, err
+	}
+	f, err := os.OpenFile(data, os.O_CREATE|os.O_WRONLY, 0664)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	if err := f.Truncate(offset); err != nil {
+		return 0, err
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return 0, err
+	}
+	n, copyErr := io.Copy(f, body)
+	// Sync even after an error: the bytes that did arrive are what the next Range request builds on
+	syncErr := f.Sync()
+	return offset + n, errors.Join(copyErr, syncErr)
+}
+
+// commitPartial moves a completed partial download to its final name and records it in the manifest.
+func (s *Store) commitPartial(p partial) (Entry, error) {
+	data, meta, err := s.partialPaths(p.URL)
+	if err != nil {
+		return Entry{}, err
+	}
+	rel, err := PathFor(p.URL, p.ContentType)
+	if err != nil {
+		return Entry{}, err
+	}
+	name := filepath.Join(s.dir, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(name), 0775); err != nil {
+		return Entry{}, err
+	}
+	info, err := os.Stat(data)
+	if err != nil {
+		return Entry{}, err
+	}
+	// Both names are inside the store directory, so this rename is atomic too
+	if err := os.Rename(data, name); err != nil {
+		return Entry{}, fmt.Errorf("content: moving %s into place: %w", filepath.Base(data), err)
+	}
+	os.Remove(meta)
+
+	now := time.Now()
+	e := Entry{
+		URL: p.URL, File: rel, Status: p.Status, Size: info.Size(), ContentType: p.ContentType,
+		FetchedAt: now, CheckedAt: now, ETag: p.ETag, LastModified: p.LastModified,
+	}
+	s.put(e)
+	return e, nil
+}
+
+// removePartial deletes rawURL's partial download, if any
+func (s *Store) removePartial(rawURL string) {
+	data, meta, err := s.partialPaths(rawURL)
+	if err != nil {
+		return
+	}
+	os.Remove(data)
+	os.Remove(meta)
+}
@@ -0,0 +1,139 @@
+package content
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"net/url"
+	"path"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxSlugLen keeps file names well below the 255-byte limit of common file systems
+const maxSlugLen = 80
+
+// PathFor returns where the body of rawURL is stored, relative to the store directory,
+// using forward slashes: "<host>/<slug>-<hash><ext>".
+//
+//   - host is lower-cased, and a port is kept as "_8080" because ":" is not allowed on Windows
+//   - slug is the URL path made safe: only letters, digits, ".", "_" and "-", at most 80 bytes
+//   - hash is the first 16 hex digits of the SHA-256 of the whole URL (query included),
+//     so two different URLs never share a file, even when their slugs are equal
+//   - ext comes from the URL path if it has one, otherwise from contentType, otherwise ".bin"
+//
+// No part can be "..", so a path never leaves the store directory. For example,
+// https://a.com/x/y?q=1 served as "text/html" is stored as a.com/x_y-<hash>.html.
+func PathFor(rawURL, contentType string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("content: URL %q has no host", rawURL)
+	}
+
+	hash := urlHash(u)
+	host := sanitize(strings.ReplaceAll(strings.ToLower(u.Host), ":", "_"))
+
+	// Split the extension off the last path segment, e.g. "/css/site.css" -> "css/site" + ".css"
+	p := strings.Trim(u.Path, "/")
+	ext := path.Ext(p)
+	if !safeExt(ext) {
+		ext = ""
+	}
+	p = strings.TrimSuffix(p, ext)
+
+	slug := sanitize(strings.ReplaceAll(p, "/", "_"))
+	slug = truncate(slug, maxSlugLen)
+	if slug == "" {
+		slug = "index"
+	}
+
+	if ext == "" {
+		ext = extensionFor(contentType)
+	}
+	return host + "/" + slug + "-" + hash + strings.ToLower(ext), nil
+}
+
+// urlHash returns the first 16 hex digits of the SHA-256 of u without its fragment
+func urlHash(u *url.URL) string {
+	// The fragment never reaches the server, so it must not create a second copy
+	c := *u
+	c.Fragment, c.RawFragment = "", ""
+	sum := sha256.Sum256([]byte(c.String()))
+	return hex.EncodeToString(sum[:8])
+}
+
+// sanitize replaces every run of characters that are not letters, digits, '.', '_' or '-'
+// with a single '_' and makes sure the result does not start with a dot (no hidden files, no "..")
+func sanitize(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r), r == '.', r == '_', r == '-':
+			b.WriteRune(r)
+		case !strings.HasSuffix(b.String(), "_"):
+			b.WriteByte('_')
+		}
+	}
+	return strings.TrimLeft(b.String(), ".")
+}
+
+// truncate cuts s to at most n bytes without splitting a UTF-8 character
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+// safeExt reports whether ext looks like a real extension: a dot and 1 to 8 letters or digits
+func safeExt(ext string) bool {
+	if len(ext) < 2 || len(ext) > 9 {
+		return false
+	}
+	for _, c := range []byte(ext[1:]) {
+		if !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// commonExtensions overrides mime.ExtensionsByType, which may return a rare extension first
+// (e.g. ".htm" or ".jfif") depending on the system's MIME tables
+var commonExtensions = map[string]string{
+	"text/html":              ".html",
+	"text/plain":             ".txt",
+	"text/css":               ".css",
+	"text/javascript":        ".js",
+	"application/javascript": ".js",
+	"application/json":       ".json",
+	"application/xml":        ".xml",
+	"image/jpeg":             ".jpg",
+	"image/png":              ".png",
+	"image/gif":              ".gif",
+	"image/svg+xml":          ".svg",
+	"image/webp":             ".webp",
+}
+
+// extensionFor picks a file extension for a Content-Type such as "text/html; charset=utf-8"
+func extensionFor(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ".bin"
+	}
+	if ext, ok := commonExtensions[mediaType]; ok {
+		return ext
+	}
+	if exts, _ := mime.ExtensionsByType(mediaType); len(exts) > 0 {
+		return exts[0]
+	}
+	return ".bin"
+}
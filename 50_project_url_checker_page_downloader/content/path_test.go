@@ -0,0 +1,67 @@
+package content
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestSanitize(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"snake_case_name", "snake_case_name"},
+		{"__init__", "__init__"},
+		{"a-b.c_d", "a-b.c_d"},
+		{"a b?c", "a_b_c"},
+		{"a  &&  b", "a_b"},
+		{"a_?b", "a_b"}, // A kept '_' and a replaced run do not add up to two
+		{"café_世界", "café_世界"},
+		{"../etc/passwd", "_etc_passwd"},
+		{"..hidden", "hidden"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := sanitize(tt.in); got != tt.want {
+			t.Errorf("sanitize(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+// hashPattern matches the hash PathFor puts before the extension
+var hashPattern = regexp.MustCompile(`-[0-9a-f]{16}\.`)
+
+func TestPathFor(t *testing.T) {
+	tests := []struct {
+		url, contentType string
+		want             string // With <hash> for the hash
+	}{
+		{"https://a.com/x/y?q=1", "text/html", "a.com/x_y-<hash>.html"},
+		{"https://A.com:8080/", "text/plain; charset=utf-8", "a.com_8080/index-<hash>.txt"},
+		{"https://a.com/my_page/read_me.MD", "", "a.com/my_page_read_me-<hash>.md"},
+		{"https://a.com/a b/..", "", "a.com/a_b_..-<hash>.bin"},
+		{"https://a.com/data", "application/x-nothing-known", "a.com/data-<hash>.bin"},
+		{"https://a.com/" + strings.Repeat("é", 60), "", "a.com/" + strings.Repeat("é", 40) + "-<hash>.bin"},
+	}
+	for _, tt := range tests {
+		got, err := PathFor(tt.url, tt.contentType)
+		if err != nil {
+			t.Fatalf("PathFor(%q) error = %v", tt.url, err)
+		}
+		if hashPattern.ReplaceAllString(got, "-<hash>.") != tt.want {
+			t.Errorf("PathFor(%q, %q) = %q, want %q", tt.url, tt.contentType, got, tt.want)
+		}
+	}
+
+	// The query changes the hash, the fragment does not
+	p1, _ := PathFor("https://a.com/x?q=1", "")
+	p2, _ := PathFor("https://a.com/x?q=2", "")
+	p3, _ := PathFor("https://a.com/x?q=1#top", "")
+	if p1 == p2 || p1 != p3 {
+		t.Errorf("paths %q, %q, %q: want the first two different and the first and last equal", p1, p2, p3)
+	}
+
+	for _, bad := range []string{"/no/host", "http://[::1"} {
+		if _, err := PathFor(bad, ""); err == nil {
+			t.Errorf("PathFor(%q) succeeded", bad)
+		}
+	}
+}
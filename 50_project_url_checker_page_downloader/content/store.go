@@ -0,0 +1,246 @@
+// Package content stores downloaded pages on disk under safe, collision-free file names
+// and keeps a manifest of what was downloaded from where.
+package content
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ManifestFile is the name of the manifest inside the store directory
+const ManifestFile = "manifest.json"
+
+// Entry describes one stored body
+type Entry struct {
+	URL         string    `json:"-"`    // The manifest is keyed by URL already
+	File        string    `json:"file"` // Relative to the store directory, with forward slashes
+	Status      int       `json:"status"`
+	Size        int64     `json:"size"`
+	ContentType string    `json:"content_type,omitempty"`
+	FetchedAt   time.Time `json:"fetched_at"`
+
+	// Validators for conditional requests (see Fetch); empty if the server sent none
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	CheckedAt    time.Time `json:"checked_at,omitzero"` // Last time the server confirmed the copy is current (304)
+}
+
+// Store saves bodies below a directory and remembers them in a manifest (manifest.json),
+// a JSON object that maps every URL to its Entry.
+//
+// Every file, the manifest included, is first written to a temporary file in the same
+// directory and then renamed over the final name. A rename within one directory is atomic,
+// so a crash or Ctrl-C never leaves a half-written file behind under a real name.
+//
+// ✅ Safe for concurrent use by multiple goroutines.
+type Store struct {
+	dir string
+
+	mu      sync.Mutex
+	entries map[string]Entry
+	dirty   bool // entries changed since the manifest was last written
+}
+
+// Open opens the store in dir, creating the directory if needed,
+// and loads its manifest if there is one.
+func Open(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0775); err != nil {
+		return nil, err
+	}
+	s := &Store{dir: dir, entries: make(map[string]Entry)}
+
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &s.entries); err != nil {
+		return nil, fmt.Errorf("content: reading %s: %w", ManifestFile, err)
+	}
+	for url, e := range s.entries {
+		e.URL = url
+		s.entries[url] = e
+	}
+	return s, nil
+}
+
+// Dir returns the store directory
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// Entry returns what the manifest knows about url; ok is false if it was never saved.
+func (s *Store) Entry(url string) (e Entry, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok = s.entries[url]
+	return e, ok
+}
+
+// Entries returns a copy of the whole manifest, keyed by URL.
+func (s *Store) Entries() map[string]Entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return maps.Clone(s.entries)
+}
+
+// Save writes body to the file PathFor(url, contentType) and records it in the manifest.
+// It returns the new entry; FetchedAt is set to now.
+//
+// The file is replaced atomically. If url was stored under a different name before
+// (its content type changed), the old file is removed.
+// The manifest itself is only written by Flush.
+func (s *Store) Save(url string, status int, contentType string, body io.Reader) (Entry, error) {
+	rel, err := PathFor(url, contentType)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	var size int64
+	err = writeFileAtomic(filepath.Join(s.dir, filepath.FromSlash(rel)), func(w io.Writer) error {
+		size, err = io.Copy(w, body)
+		return err
+	})
+	if err != nil {
+		return Entry{}, err
+	}
+
+	e := Entry{URL: url, File: rel, Status: status, Size: size, ContentType: contentType, FetchedAt: time.Now()}
+	s.put(e)
+	return e, nil
+}
+
+// put records e in the manifest. If e.URL was stored under a different name before, the old file is removed.
+func (s *Store) put(e Entry) {
+	s.mu.Lock()
+	old, existed := s.entries[e.URL]
+	s.entries[e.URL] = e
+	s.dirty = true
+	s.mu.Unlock()
+
+	if existed && old.File != e.File {
+		os.Remove(filepath.Join(s.dir, filepath.FromSlash(old.File)))
+	}
+}
+
+// Rewrite replaces the stored body of url with what transform writes while reading the old body,
+// e.g. to rewrite the links of a page. The file is replaced atomically and the entry's Size updated.
+// It returns an error if url is not in the store.
+func (s *Store) Rewrite(url string, transform func(r io.Reader, w io.Writer) error) error {
+	e, ok := s.Entry(url)
+	if !ok {
+		return fmt.Errorf("content: %s is not stored", url)
+	}
+	name := filepath.Join(s.dir, filepath.FromSlash(e.File))
+
+	// Read the old body first: some systems cannot rename over a file that is still open
+	old, err := os.ReadFile(name)
+	if err != nil {
+		return err
+	}
+
+	var size int64
+	err = writeFileAtomic(name, func(w io.Writer) error {
+		cw := &countingWriter{w: w}
+		err := transform(bytes.NewReader(old), cw)
+		size = cw.n
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// Only update the entry if nobody saved a newer body in the meantime
+	if cur, ok := s.entries[url]; ok && cur.File == e.File {
+		cur.Size = size
+		s.entries[url] = cur
+		s.dirty = true
+	}
+	return nil
+}
+
+// countingWriter counts the bytes written through it
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
+
+// Flush writes the manifest (atomically) if anything changed since the last Flush.
+func (s *Store) Flush() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.dirty {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(s.entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	err = writeFileAtomic(filepath.Join(s.dir, ManifestFile), func(w io.Writer) error {
+		_, err := w.Write(append(data, '\n'))
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	s.dirty = false
+	return nil
+}
+
+// writeFileAtomic creates the parent directories of name, lets write fill a temporary file
+// next to it, and renames the temporary file to name once it is complete and synced.
+// On any error the temporary file is removed and name is left untouched.
+func writeFileAtomic(name string, write func(w io.Writer) error) (err error) {
+	dir := filepath.Dir(name)
+	if err := os.MkdirAll(dir, 0775); err != nil {
+		return err
+	}
+
+	// The temporary file must be in the same directory: rename is only atomic within one file system
+	tmp, err := os.CreateTemp(dir, ".tmp-"+filepath.Base(name)+"-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	if err := write(tmp); err != nil {
+		return err
+	}
+	// Make sure the data is on disk before the new name points at it
+	if err := tmp.Sync(); err != nil {
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	// CreateTemp uses mode 0600; downloaded pages are meant to be shared like before
+	if err := os.Chmod(tmp.Name(), 0664); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), name)
+}
@@ -0,0 +1,361 @@
+// Package crawl mirrors web sites: starting from some pages, it follows the links
+// within the same host up to a depth limit, saves everything into a content.Store,
+// and rewrites the links of the saved pages so the copy can be browsed offline.
+//
+// It honours robots.txt (including Crawl-delay) and never sends requests
+// to one host more often than Options.Delay allows.
+package crawl
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"master_go_programming/50_project_url_checker_page_downloader/content"
+	"master_go_programming/50_project_url_checker_page_downloader/pool"
+	"master_go_programming/50_project_url_checker_page_downloader/retry"
+)
+
+// DefaultUserAgent is sent when Options.UserAgent is empty.
+// Its first word is the name robots.txt groups are matched against.
+const DefaultUserAgent = "url-checker/1.0"
+
+// ErrOffsiteRedirect is the Page.Err of a URL that redirects to another host.
+// The redirect is not followed, so nothing outside the start hosts is fetched or saved.
+var ErrOffsiteRedirect = errors.New("crawl: redirect to another host not followed")
+
+// Options configures a crawl
+type Options struct {
+	MaxDepth    int           // Link hops followed from the start pages (0 = only the start pages)
+	Concurrency int           // Requests in flight at once (values below 1 mean 1)
+	Delay       time.Duration // Minimum time between two requests to the same host
+	UserAgent   string        // "" = DefaultUserAgent
+	Client      *http.Client  // nil = a client with a 30 second timeout
+	Policy      retry.Policy  // Retries of temporary failures; the zero value never retries
+	OnPage      func(Page)    // Called after every URL is done, e.g. to show progress; may be nil
+}
+
+// Page is what happened to one URL of the crawl
+type Page struct {
+	URL        string
+	FinalURL   string // Where the page really is after redirects; its relative links resolve against it
+	Depth      int    // Link hops from a start page
+	Asset      bool   // Found in <img>, <script> or <link> rather than <a>
+	Status     int    // 0 when no response arrived
+	Attempts   int
+	Elapsed    time.Duration
+	File       string // Where it was saved, relative to the store; "" if it was not
+	Disallowed bool   // robots.txt does not allow it, or the URL it redirects to, so it was not fetched or followed
+	Err        error
+}
+
+// task is a URL waiting to be fetched
+type task struct {
+	url   string
+	depth int
+	asset bool
+}
+
+// crawler is the state shared by the workers of one Crawl call
+type crawler struct {
+	opts    Options
+	store   *content.Store
+	hosts   map[string]bool // Hosts of the start pages; no other host is visited
+	seen    map[string]bool // Normalized URLs already queued; only used between levels
+	limiter *hostLimiter
+
+	// robotsClient fetches robots.txt. Unlike opts.Client, its redirects do not look up
+	// robots.txt themselves, which would wait for the very fetch they are part of.
+	robotsClient *http.Client
+
+	mu     sync.Mutex
+	robots map[string]*hostRobots // Per scheme://host
+}
+
+// hostRobots fetches the robots.txt of one host once, however many workers need it.
+// The other workers wait on mu meanwhile.
+type hostRobots struct {
+	mu sync.Mutex
+	r  *robots // nil until fetched
+}
+
+// Crawl mirrors the sites of start into store and returns what happened to every URL,
+// in the order they were found. When ctx is cancelled, it stops fetching and still
+// rewrites the links of the pages saved so far.
+//
+// Links are rewritten in every saved HTML page: links to saved URLs point to the local
+// files, every other link becomes an absolute URL, so it still works from the offline copy.
+func Crawl(ctx context.Context, start []string, store *content.Store, opts Options) ([]Page, error) {
+	if opts.UserAgent == "" {
+		opts.UserAgent = DefaultUserAgent
+	}
+	if opts.Client == nil {
+		opts.Client = &http.Client{Timeout: 30 * time.Second}
+	}
+	c := &crawler{
+		opts:    opts,
+		store:   store,
+		hosts:   make(map[string]bool),
+		limiter: newHostLimiter(),
+		seen:    make(map[string]bool),
+		robots:  make(map[string]*hostRobots),
+	}
+
+	var level []task
+	for _, s := range start {
+		u, ok := normalize(s, &url.URL{})
+		if !ok {
+			return nil, fmt.Errorf("crawl: invalid start URL %q", s)
+		}
+		parsed, _ := url.Parse(u)
+		c.hosts[parsed.Host] = true
+		if !c.seen[u] {
+			c.seen[u] = true
+			level = append(level, task{url: u})
+		}
+	}
+	c.robotsClient = c.sameHostClient(opts.Client, func(req *http.Request) error {
+		return c.limiter.wait(req.Context(), req.URL.Host, c.opts.Delay)
+	})
+	c.opts.Client = c.sameHostClient(opts.Client, func(req *http.Request) error {
+		rules := c.robotsFor(req.Context(), req.URL)
+		if !rules.allowed(req.URL.RequestURI()) {
+			// fetch sees the redirect and marks the page Disallowed
+			return http.ErrUseLastResponse
+		}
+		return c.limiter.wait(req.Context(), req.URL.Host, max(c.opts.Delay, rules.crawlDelay))
+	})
+
+	// Breadth first, one depth at a time: every page at depth d is fetched before any at d+1
+	var pages []Page
+	for len(level) > 0 && ctx.Err() == nil {
+		results := make([]Page, len(level))
+		found := make([][]link, len(level))
+		pool.Run(ctx, max(opts.Concurrency, 1), len(level), func(i int) {
+			results[i], found[i] = c.fetch(ctx, level[i])
+			if opts.OnPage != nil {
+				opts.OnPage(results[i])
+			}
+		})
+
+		var next []task
+		for i, t := range level {
+			// Pages the workers never got to because of cancellation have no attempts and no verdict
+			if results[i].URL == "" {
+				continue
+			}
+			pages = append(pages, results[i])
+			next = append(next, c.follow(t, found[i])...)
+		}
+		level = next
+	}
+
+	if err := rewriteLinks(store, pages); err != nil {
+		return pages, err
+	}
+	return pages, nil
+}
+
+// sameHostClient returns a copy of client that does not follow redirects to hosts
+// outside c.hosts: the redirect response itself is returned instead.
+// Every redirect it does follow is passed to before first, which waits for its turn
+// in c.limiter like any other request.
+func (c *crawler) sameHostClient(client *http.Client, before func(req *http.Request) error) *http.Client {
+	copied := *client
+	next := client.CheckRedirect
+	copied.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if !c.hosts[req.URL.Host] {
+			return http.ErrUseLastResponse
+		}
+		if next != nil {
+			if err := next(req, via); err != nil {
+				return err
+			}
+		} else if len(via) >= 10 {
+			// The limit of http.Client's default policy
+			return errors.New("stopped after 10 redirects")
+		}
+		return before(req)
+	}
+	return &copied
+}
+
+// follow returns the links of a page that still have to be fetched
+func (c *crawler) follow(t task, links []link) []task {
+	var next []task
+	for _, l := range links {
+		u, _ := url.Parse(l.url)
+		// Assets are needed to display the page, so they do not count against the depth limit
+		if !c.hosts[u.Host] || c.seen[l.url] || (!l.asset && t.depth >= c.opts.MaxDepth) {
+			continue
+		}
+		c.seen[l.url] = true
+		next = append(next, task{url: l.url, depth: t.depth + 1, asset: l.asset})
+	}
+	return next
+}
+
+// fetch downloads one URL, saves it if it answered 200 OK,
+// and returns the links of HTML pages (but not of assets, which are never followed).
+func (c *crawler) fetch(ctx context.Context, t task) (Page, []link) {
+	p := Page{URL: t.url, Depth: t.depth, Asset: t.asset}
+	u, _ := url.Parse(t.url)
+
+	rules := c.robotsFor(ctx, u)
+	if !rules.allowed(u.RequestURI()) {
+		p.Disallowed = true
+		return p, nil
+	}
+	if err := c.limiter.wait(ctx, u.Host, max(c.opts.Delay, rules.crawlDelay)); err != nil {
+		p.Err = err
+		return p, nil
+	}
+
+	start := time.Now()
+	defer func() { p.Elapsed = time.Since(start) }()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, t.url, nil)
+	if err != nil {
+		p.Err = err
+		return p, nil
+	}
+	req.Header.Set("User-Agent", c.opts.UserAgent)
+
+	resp, attempts, err := c.opts.Policy.Do(ctx, c.opts.Client, req)
+	p.Attempts = attempts
+	if err != nil {
+		p.Err = err
+		return p, nil
+	}
+	defer resp.Body.Close()
+	p.Status = resp.StatusCode
+	p.FinalURL = resp.Request.URL.String()
+
+	if resp.StatusCode != http.StatusOK {
+		io.Copy(io.Discard, resp.Body)
+		loc, err := resp.Location()
+		switch {
+		case err != nil || !isRedirect(resp.StatusCode):
+		case !c.hosts[loc.Host]:
+			p.Err = fmt.Errorf("%w: %s", ErrOffsiteRedirect, loc)
+		case !c.robotsFor(ctx, loc).allowed(loc.RequestURI()):
+			p.Disallowed = true
+		}
+		return p, nil
+	}
+
+	// Keep a copy of HTML pages while saving them, to look for links afterwards
+	contentType := resp.Header.Get("Content-Type")
+	var page bytes.Buffer
+	body := io.Reader(resp.Body)
+	if !t.asset && isHTML(contentType) {
+		body = io.TeeReader(resp.Body, &page)
+	}
+
+	e, err := c.store.Save(t.url, resp.StatusCode, contentType, body)
+	if err != nil {
+		p.Err = fmt.Errorf("saving body: %w", err)
+		return p, nil
+	}
+	p.File = e.File
+
+	if page.Len() == 0 {
+		return p, nil
+	}
+	// Links are relative to where the page really is, after any redirect
+	links, err := extractLinks(&page, resp.Request.URL)
+	if err != nil {
+		p.Err = fmt.Errorf("parsing HTML: %w", err)
+	}
+	return p, links
+}
+
+// robotsFor returns the robots.txt rules of u's host, fetching them the first time.
+// A missing robots.txt (4xx) allows everything; an unreachable one (5xx or no answer)
+// disallows everything, as RFC 9309 asks.
+//
+// A fetch cut short because ctx was done disallows everything too, but is not remembered:
+// ctx belongs to one request, and the next worker asking tries again.
+func (c *crawler) robotsFor(ctx context.Context, u *url.URL) *robots {
+	key := u.Scheme + "://" + u.Host
+	c.mu.Lock()
+	hr, ok := c.robots[key]
+	if !ok {
+		hr = &hostRobots{}
+		c.robots[key] = hr
+	}
+	c.mu.Unlock()
+
+	hr.mu.Lock()
+	defer hr.mu.Unlock()
+	if hr.r != nil {
+		return hr.r
+	}
+	r := c.fetchRobots(ctx, key+"/robots.txt", u.Host)
+	if ctx.Err() == nil {
+		hr.r = r
+	}
+	return r
+}
+
+// fetchRobots downloads and parses one robots.txt
+func (c *crawler) fetchRobots(ctx context.Context, robotsURL, host string) *robots {
+	if err := c.limiter.wait(ctx, host, c.opts.Delay); err != nil {
+		return &robots{disallowAll: true}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, robotsURL, nil)
+	if err != nil {
+		return &robots{disallowAll: true}
+	}
+	req.Header.Set("User-Agent", c.opts.UserAgent)
+
+	resp, _, err := c.opts.Policy.Do(ctx, c.robotsClient, req)
+	if err != nil {
+		return &robots{disallowAll: true}
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 500:
+		return &robots{disallowAll: true}
+	case resp.StatusCode >= 300:
+		// Missing, or redirected to another host: RFC 9309 treats both as "unavailable"
+		return &robots{}
+	}
+	// Like Google, read at most 500 KiB of rules
+	return parseRobots(io.LimitReader(resp.Body, 500<<10), agentToken(c.opts.UserAgent))
+}
+
+// agentToken returns the product name of a User-Agent, e.g. "url-checker" for "url-checker/1.0 (...)"
+func agentToken(userAgent string) string {
+	for i, r := range userAgent {
+		if r == '/' || r == ' ' {
+			return userAgent[:i]
+		}
+	}
+	return userAgent
+}
+
+// isRedirect reports whether status is one of the redirects http.Client follows
+func isRedirect(status int) bool {
+	switch status {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther,
+		http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		return true
+	}
+	return false
+}
+
+// isHTML reports whether a Content-Type is an HTML page
+func isHTML(contentType string) bool {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	return mediaType == "text/html" || mediaType == "application/xhtml+xml"
+}
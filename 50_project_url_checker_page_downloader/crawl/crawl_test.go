@@ -0,0 +1,396 @@
+package crawl
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"master_go_programming/50_project_url_checker_page_downloader/content"
+)
+
+// testSite is a small web site served by httptest that records every request
+type testSite struct {
+	*httptest.Server
+	other    string // Replaces {other} in the pages
+	mu       sync.Mutex
+	requests []siteRequest
+}
+
+type siteRequest struct {
+	path      string
+	userAgent string
+	at        time.Time
+}
+
+// sitePages maps paths to HTML bodies; {other} is replaced by the URL of a second server
+var sitePages = map[string]string{
+	"/robots.txt": "User-agent: *\nDisallow: /private\n",
+	"/": `<html><head><link rel="stylesheet" href="/style.css"></head><body>
+		<a href="/a">a</a> <a href="/a#top">a again</a> <a href="./x/../a">and again</a>
+		<a href="/private/secret">private</a> <a href="{other}/">other site</a>
+		<a href="/docs">docs</a> <a href="/away">away</a> <a href="/base">base</a> <a href="/go">go</a>
+		<img src="/logo.png"> <a href="mailto:me@example.com">mail</a>
+	</body></html>`,
+	"/a":              `<a href="/b">b</a> <a href="/">home</a>`,
+	"/b":              `<a href="/c">c</a> <img src="/deep.png">`,
+	"/c":              `<a href="/d">d</a>`,
+	"/docs/":          `<a href="guide">guide</a>`,
+	"/docs/guide":     `<a href="../">docs</a>`,
+	"/base":           `<base href="/sub/"><a href="page">page</a>`,
+	"/sub/page":       `sub page`,
+	"/private/secret": `secret`,
+}
+
+// newTestSite starts the site; the second server is the "other host" links and redirects point to
+func newTestSite(t *testing.T) (site, other *testSite) {
+	other = &testSite{}
+	other.Server = httptest.NewServer(http.HandlerFunc(other.serve))
+	t.Cleanup(other.Close)
+
+	site = &testSite{other: other.URL}
+	site.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/docs":
+			site.record(r)
+			http.Redirect(w, r, "/docs/", http.StatusMovedPermanently)
+		case "/away":
+			site.record(r)
+			http.Redirect(w, r, other.URL+"/landing", http.StatusFound)
+		case "/go":
+			site.record(r)
+			http.Redirect(w, r, "/private/secret", http.StatusFound)
+		default:
+			site.serve(w, r)
+		}
+	}))
+	t.Cleanup(site.Close)
+	return site, other
+}
+
+func (s *testSite) record(r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, siteRequest{path: r.URL.Path, userAgent: r.UserAgent(), at: time.Now()})
+}
+
+func (s *testSite) serve(w http.ResponseWriter, r *http.Request) {
+	s.record(r)
+	switch {
+	case strings.HasSuffix(r.URL.Path, ".png"):
+		w.Header().Set("Content-Type", "image/png")
+		io.WriteString(w, "\x89PNG")
+	case strings.HasSuffix(r.URL.Path, ".css"):
+		w.Header().Set("Content-Type", "text/css")
+		io.WriteString(w, "body{}")
+	default:
+		body, ok := sitePages[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		if r.URL.Path != "/robots.txt" {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		}
+		io.WriteString(w, strings.ReplaceAll(body, "{other}", s.other))
+	}
+}
+
+// fetched returns how often each path was requested
+func (s *testSite) fetched() map[string]int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	counts := make(map[string]int)
+	for _, r := range s.requests {
+		counts[r.path]++
+	}
+	return counts
+}
+
+// crawlSite crawls site from / and returns the pages and the store
+func crawlSite(t *testing.T, site *testSite, opts Options) ([]Page, *content.Store) {
+	t.Helper()
+	store, err := content.Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	pages, err := Crawl(context.Background(), []string{site.URL + "/"}, store, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pages, store
+}
+
+// savedHTML returns the saved, rewritten copy of url
+func savedHTML(t *testing.T, store *content.Store, url string) string {
+	t.Helper()
+	e, ok := store.Entry(url)
+	if !ok {
+		t.Fatalf("%s was not saved", url)
+	}
+	b, err := os.ReadFile(filepath.Join(store.Dir(), filepath.FromSlash(e.File)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+// localPath returns the link from the saved page from to the saved page to
+func localPath(t *testing.T, store *content.Store, from, to string) string {
+	t.Helper()
+	f, _ := store.Entry(from)
+	e, ok := store.Entry(to)
+	if !ok {
+		t.Fatalf("%s was not saved", to)
+	}
+	rel, err := filepath.Rel(filepath.Dir(filepath.FromSlash(f.File)), filepath.FromSlash(e.File))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// pageFor returns the Page of url
+func pageFor(t *testing.T, pages []Page, url string) Page {
+	t.Helper()
+	for _, p := range pages {
+		if p.URL == url {
+			return p
+		}
+	}
+	t.Fatalf("%s was not crawled", url)
+	return Page{}
+}
+
+func TestCrawlRedirects(t *testing.T) {
+	site, other := newTestSite(t)
+	pages, store := crawlSite(t, site, Options{MaxDepth: 2, Concurrency: 4})
+
+	// /docs redirects to /docs/: its relative link "guide" means /docs/guide,
+	// both when following it and when rewriting it
+	docs := pageFor(t, pages, site.URL+"/docs")
+	if docs.FinalURL != site.URL+"/docs/" {
+		t.Errorf("FinalURL of /docs = %q, want %q", docs.FinalURL, site.URL+"/docs/")
+	}
+	pageFor(t, pages, site.URL+"/docs/guide")
+	want := `href="` + localPath(t, store, site.URL+"/docs", site.URL+"/docs/guide") + `"`
+	if html := savedHTML(t, store, site.URL+"/docs"); !strings.Contains(html, want) {
+		t.Errorf("redirected page: want %s in\n%s", want, html)
+	}
+
+	// /away redirects to the other host, which must never be contacted
+	away := pageFor(t, pages, site.URL+"/away")
+	if away.Status != http.StatusFound || away.File != "" || !errors.Is(away.Err, ErrOffsiteRedirect) {
+		t.Errorf("/away = status %d, file %q, err %v; want 302, not saved, ErrOffsiteRedirect", away.Status, away.File, away.Err)
+	}
+	if n := len(other.fetched()); n != 0 {
+		t.Errorf("the other host got %d requests: %v", n, other.fetched())
+	}
+}
+
+func TestCrawl(t *testing.T) {
+	site, _ := newTestSite(t)
+	pages, _ := crawlSite(t, site, Options{MaxDepth: 2, Concurrency: 4, UserAgent: "mirror-test/2.0"})
+	fetched := site.fetched()
+
+	// Every URL is fetched once, however it is spelled: /a, /a#top and ./x/../a are the same page
+	for path, n := range fetched {
+		if n != 1 {
+			t.Errorf("%s fetched %d times", path, n)
+		}
+	}
+	if n := len(pages); n != len(fetched)-1 { // robots.txt is not a page
+		t.Errorf("%d pages for %d fetched paths", n, len(fetched))
+	}
+
+	// robots.txt disallows /private
+	if fetched["/private/secret"] != 0 {
+		t.Error("fetched /private/secret, which robots.txt disallows")
+	}
+	if p := pageFor(t, pages, site.URL+"/private/secret"); !p.Disallowed {
+		t.Errorf("/private/secret: Disallowed = false")
+	}
+	// ... also when an allowed page redirects there
+	if p := pageFor(t, pages, site.URL+"/go"); !p.Disallowed || p.Status != http.StatusFound || p.File != "" {
+		t.Errorf("/go = disallowed %v, status %d, file %q; want true, 302, not saved", p.Disallowed, p.Status, p.File)
+	}
+
+	// Depth: / is 0, /a 1, /b 2; /c would be 3. Assets do not count, so /b's image is fetched.
+	for _, path := range []string{"/", "/a", "/b", "/deep.png", "/style.css", "/logo.png"} {
+		if fetched[path] != 1 {
+			t.Errorf("%s was not fetched", path)
+		}
+	}
+	if fetched["/c"] != 0 {
+		t.Error("fetched /c, which is deeper than MaxDepth")
+	}
+	if p := pageFor(t, pages, site.URL+"/b"); p.Depth != 2 || p.Asset {
+		t.Errorf("/b: depth %d, asset %v; want 2, false", p.Depth, p.Asset)
+	}
+	if p := pageFor(t, pages, site.URL+"/deep.png"); p.Depth != 3 || !p.Asset {
+		t.Errorf("/deep.png: depth %d, asset %v; want 3, true", p.Depth, p.Asset)
+	}
+
+	// <base href="/sub/"> makes "page" mean /sub/page
+	if fetched["/sub/page"] != 1 || fetched["/page"] != 0 {
+		t.Errorf("<base> not honoured: /sub/page fetched %d times, /page %d times", fetched["/sub/page"], fetched["/page"])
+	}
+
+	for _, r := range site.requests {
+		if r.userAgent != "mirror-test/2.0" {
+			t.Fatalf("request for %s sent User-Agent %q", r.path, r.userAgent)
+		}
+	}
+}
+
+func TestCrawlRewritesLinks(t *testing.T) {
+	site, other := newTestSite(t)
+	_, store := crawlSite(t, site, Options{MaxDepth: 2, Concurrency: 4})
+	root := site.URL + "/"
+
+	html := savedHTML(t, store, root)
+	want := []string{
+		// Saved pages and assets: relative paths to the local files, keeping the fragment
+		`href="` + localPath(t, store, root, site.URL+"/a") + `"`,
+		`href="` + localPath(t, store, root, site.URL+"/a") + `#top"`,
+		`src="` + localPath(t, store, root, site.URL+"/logo.png") + `"`,
+		`href="` + localPath(t, store, root, site.URL+"/style.css") + `"`,
+		// Not saved: absolute links to the real site
+		`href="` + site.URL + `/private/secret"`,
+		`href="` + other.URL + `/"`,
+		// Not a web link: left alone
+		`href="mailto:me@example.com"`,
+	}
+	for _, w := range want {
+		if !strings.Contains(html, w) {
+			t.Errorf("want %s in the saved /:\n%s", w, html)
+		}
+	}
+
+	// /b links to /c, which is too deep to be saved
+	if html := savedHTML(t, store, site.URL+"/b"); !strings.Contains(html, `href="`+site.URL+`/c"`) {
+		t.Errorf("the link to /c should point at the real site:\n%s", html)
+	}
+
+	// The <base> element is dropped, since the rewritten links are relative to the file itself
+	html = savedHTML(t, store, site.URL+"/base")
+	if strings.Contains(html, "<base") || !strings.Contains(html, `href="`+localPath(t, store, site.URL+"/base", site.URL+"/sub/page")+`"`) {
+		t.Errorf("saved /base:\n%s", html)
+	}
+}
+
+func TestCrawlDelay(t *testing.T) {
+	const delay = 30 * time.Millisecond
+	site, _ := newTestSite(t)
+	crawlSite(t, site, Options{MaxDepth: 1, Concurrency: 8, Delay: delay})
+
+	// However many workers there are, requests to one host start at least delay apart
+	site.mu.Lock()
+	defer site.mu.Unlock()
+	if len(site.requests) < 5 {
+		t.Fatalf("only %d requests", len(site.requests))
+	}
+	for i := 1; i < len(site.requests); i++ {
+		// A little slack: the timestamps are taken in the handler, not when the request starts
+		if gap := site.requests[i].at.Sub(site.requests[i-1].at); gap < delay-5*time.Millisecond {
+			t.Errorf("requests %s and %s only %v apart", site.requests[i-1].path, site.requests[i].path, gap)
+		}
+	}
+}
+
+func TestCrawlCancel(t *testing.T) {
+	site, _ := newTestSite(t)
+	store, err := content.Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	pages, err := Crawl(ctx, []string{site.URL + "/"}, store, Options{MaxDepth: 2})
+	if err != nil || len(pages) > 1 {
+		t.Fatalf("Crawl with a cancelled context = %d pages, %v", len(pages), err)
+	}
+	if n := len(store.Entries()); n != 0 {
+		t.Fatalf("%d pages saved after cancellation", n)
+	}
+}
+
+// TestCrawlRobotsRedirect crawls a site whose robots.txt has moved: the crawler must follow
+// the redirect to the rules without waiting for them to be fetched first, and then obey them.
+func TestCrawlRobotsRedirect(t *testing.T) {
+	site := &testSite{}
+	site.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		site.record(r)
+		switch r.URL.Path {
+		case "/robots.txt":
+			http.Redirect(w, r, "/static/robots.txt", http.StatusMovedPermanently)
+		case "/static/robots.txt":
+			io.WriteString(w, "User-agent: *\nDisallow: /private\n")
+		default:
+			w.Header().Set("Content-Type", "text/html")
+			io.WriteString(w, `<a href="/private/secret">private</a> <a href="/public">public</a>`)
+		}
+	}))
+	t.Cleanup(site.Close)
+
+	store, err := content.Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan []Page, 1)
+	go func() {
+		pages, _ := Crawl(context.Background(), []string{site.URL + "/"}, store, Options{MaxDepth: 1, Concurrency: 2})
+		done <- pages
+	}()
+	var pages []Page
+	select {
+	case pages = <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Crawl hangs when robots.txt redirects")
+	}
+
+	fetched := site.fetched()
+	if fetched["/static/robots.txt"] != 1 || fetched["/public"] != 1 {
+		t.Errorf("fetched %v; want the moved robots.txt and /public once each", fetched)
+	}
+	if fetched["/private/secret"] != 0 || !pageFor(t, pages, site.URL+"/private/secret").Disallowed {
+		t.Error("/private/secret was fetched although the moved robots.txt disallows it")
+	}
+}
+
+// TestRobotsForCancelled checks that rules fetched under a cancelled context are not kept
+// for the rest of the crawl: the next caller fetches robots.txt again.
+func TestRobotsForCancelled(t *testing.T) {
+	site, _ := newTestSite(t)
+	c := &crawler{
+		opts:    Options{UserAgent: DefaultUserAgent},
+		hosts:   map[string]bool{site.Listener.Addr().String(): true},
+		limiter: newHostLimiter(),
+		robots:  make(map[string]*hostRobots),
+	}
+	c.robotsClient = c.sameHostClient(site.Client(), func(*http.Request) error { return nil })
+	u, _ := url.Parse(site.URL + "/private/secret")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if c.robotsFor(ctx, u).allowed("/a") {
+		t.Fatal("rules fetched under a cancelled context allow /a")
+	}
+
+	rules := c.robotsFor(context.Background(), u)
+	if !rules.allowed("/a") || rules.allowed("/private/secret") {
+		t.Fatalf("rules after the cancelled fetch = %+v; want those of the site's robots.txt", rules)
+	}
+	if n := site.fetched()["/robots.txt"]; n != 1 {
+		t.Fatalf("robots.txt fetched %d times, want 1", n)
+	}
+}
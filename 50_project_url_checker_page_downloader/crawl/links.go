@@ -0,0 +1,114 @@
+package crawl
+
+import (
+	"io"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// linkAttrs lists, per tag, the attribute holding a URL the crawler follows or rewrites
+var linkAttrs = map[string]string{
+	"a":      "href",
+	"link":   "href",
+	"img":    "src",
+	"script": "src",
+}
+
+// link is one URL found in a page
+type link struct {
+	url   string // Normalized absolute URL
+	asset bool   // Needed to display the page (img, script, link) rather than a page to follow (a)
+}
+
+// extractLinks returns the normalized http(s) URLs of every <a href>, <link href>, <img src>
+// and <script src> in the HTML read from r, resolved against base, in document order.
+// A <base href> in the page replaces base for the links after it, as in a browser.
+func extractLinks(r io.Reader, base *url.URL) ([]link, error) {
+	var links []link
+	z := html.NewTokenizer(r)
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			if z.Err() == io.EOF {
+				return links, nil
+			}
+			return links, z.Err()
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			t := z.Token()
+			if t.Data == "base" {
+				if href, ok := attr(t, "href"); ok {
+					if u, err := base.Parse(href); err == nil {
+						base = u
+					}
+				}
+				continue
+			}
+
+			name, ok := linkAttrs[t.Data]
+			if !ok {
+				continue
+			}
+			ref, ok := attr(t, name)
+			if !ok {
+				continue
+			}
+			if u, ok := normalize(ref, base); ok {
+				links = append(links, link{url: u, asset: t.Data != "a"})
+			}
+		}
+	}
+}
+
+// attr returns the value of the attribute called name
+func attr(t html.Token, name string) (string, bool) {
+	for _, a := range t.Attr {
+		if a.Key == name {
+			return a.Val, true
+		}
+	}
+	return "", false
+}
+
+// normalize resolves ref against base and returns it in a canonical form,
+// so that the same page reached through different spellings is fetched once:
+//
+//   - scheme and host are lower-cased, and default ports (:80, :443) are dropped
+//   - "." and ".." path segments are resolved, and an empty path becomes "/"
+//   - the fragment (#...) is dropped, because it never reaches the server
+//
+// ok is false for anything that is not an http or https URL (mailto:, javascript:, data:, ...).
+func normalize(ref string, base *url.URL) (string, bool) {
+	u, err := base.Parse(strings.TrimSpace(ref))
+	if err != nil {
+		return "", false
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return "", false
+	}
+
+	host := strings.ToLower(u.Hostname())
+	port := u.Port()
+	if (u.Scheme == "http" && port == "80") || (u.Scheme == "https" && port == "443") {
+		port = ""
+	}
+	u.Host = host
+	if strings.Contains(host, ":") {
+		// IPv6 literals need their brackets back
+		u.Host = "[" + host + "]"
+	}
+	if port != "" {
+		u.Host += ":" + port
+	}
+
+	if u.Path == "" {
+		u.Path = "/"
+	}
+	u.Fragment = ""
+	u.RawFragment = ""
+	u.User = nil
+	return u.String(), true
+}
@@ -0,0 +1,40 @@
+package crawl
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// hostLimiter spaces out the requests to each host, however many workers there are.
+// Every call to wait reserves the next free slot of its host, so waiting workers
+// are served in the order they arrived.
+type hostLimiter struct {
+	mu   sync.Mutex
+	next map[string]time.Time // Earliest start of the next request per host
+}
+
+func newHostLimiter() *hostLimiter {
+	return &hostLimiter{next: make(map[string]time.Time)}
+}
+
+// wait blocks until a request to host may start, at least delay after the previous one.
+// It returns ctx.Err() if ctx is done first.
+func (l *hostLimiter) wait(ctx context.Context, host string, delay time.Duration) error {
+	l.mu.Lock()
+	start := time.Now()
+	if next := l.next[host]; next.After(start) {
+		start = next
+	}
+	l.next[host] = start.Add(delay)
+	l.mu.Unlock()
+
+	timer := time.NewTimer(time.Until(start))
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
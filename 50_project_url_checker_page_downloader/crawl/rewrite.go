@@ -0,0 +1,134 @@
+package crawl
+
+import (
+	"bytes"
+	"cmp"
+	"io"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/net/html"
+
+	"master_go_programming/50_project_url_checker_page_downloader/content"
+)
+
+// rewriteLinks rewrites the links of every HTML page in pages that was saved in store
+func rewriteLinks(store *content.Store, pages []Page) error {
+	for _, p := range pages {
+		e, ok := store.Entry(p.URL)
+		if p.File == "" || !ok || !isHTML(e.ContentType) {
+			continue
+		}
+		// Relative links resolve against where the page really is, as in extractLinks
+		base, err := url.Parse(cmp.Or(p.FinalURL, p.URL))
+		if err != nil {
+			continue
+		}
+		err = store.Rewrite(p.URL, func(r io.Reader, w io.Writer) error {
+			return rewriteHTML(r, w, base, e.File, store)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rewriteHTML copies the page from r to w, changing only the URL attributes of
+// <a>, <link>, <img> and <script>: a link to a URL saved in store becomes a relative path
+// from file (the page's own file) to the saved file; any other link becomes absolute.
+// <base> elements are dropped, since they would redirect the relative paths.
+// Everything else is copied byte for byte.
+func rewriteHTML(r io.Reader, w io.Writer, base *url.URL, file string, store *content.Store) error {
+	z := html.NewTokenizer(r)
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			if z.Err() == io.EOF {
+				return nil
+			}
+			return z.Err()
+		}
+		// Raw must be copied before Token, which may reuse its buffer
+		raw := bytes.Clone(z.Raw())
+
+		if tt != html.StartTagToken && tt != html.SelfClosingTagToken {
+			if _, err := w.Write(raw); err != nil {
+				return err
+			}
+			continue
+		}
+
+		t := z.Token()
+		if t.Data == "base" {
+			if href, ok := attr(t, "href"); ok {
+				if u, err := base.Parse(href); err == nil {
+					base = u
+				}
+			}
+			continue
+		}
+
+		name, ok := linkAttrs[t.Data]
+		if !ok {
+			if _, err := w.Write(raw); err != nil {
+				return err
+			}
+			continue
+		}
+
+		changed := false
+		for i, a := range t.Attr {
+			if a.Key != name {
+				continue
+			}
+			if v, ok := localLink(a.Val, base, file, store); ok {
+				t.Attr[i].Val = v
+				changed = true
+			}
+		}
+		if !changed {
+			if _, err := w.Write(raw); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, err := io.WriteString(w, t.String()); err != nil {
+			return err
+		}
+	}
+}
+
+// localLink returns what a link to ref should become in the offline copy of the page stored at file.
+// ok is false when ref is best left alone (a fragment, mailto:, javascript:, ...).
+func localLink(ref string, base *url.URL, file string, store *content.Store) (string, bool) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" || strings.HasPrefix(ref, "#") {
+		return "", false
+	}
+	target, ok := normalize(ref, base)
+	if !ok {
+		return "", false
+	}
+
+	// Keep the #fragment, which normalize dropped
+	fragment := ""
+	if abs, err := base.Parse(ref); err == nil && abs.Fragment != "" {
+		fragment = "#" + abs.EscapedFragment()
+	}
+
+	e, saved := store.Entry(target)
+	if !saved {
+		// Not downloaded: point at the real site
+		return target + fragment, true
+	}
+
+	rel, err := filepath.Rel(filepath.FromSlash(path.Dir(file)), filepath.FromSlash(e.File))
+	if err != nil {
+		return target + fragment, true
+	}
+	// url.URL escapes the path and adds "./" if the first segment could look like a scheme
+	return (&url.URL{Path: filepath.ToSlash(rel)}).String() + fragment, true
+}
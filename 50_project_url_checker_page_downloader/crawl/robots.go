@@ -0,0 +1,131 @@
+package crawl
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// robots holds the rules of one robots.txt that apply to our user agent.
+// See RFC 9309: the longest matching rule wins, and Allow wins a tie.
+type robots struct {
+	rules       []robotsRule
+	crawlDelay  time.Duration // Non-standard but common; 0 if not given
+	disallowAll bool          // robots.txt could not be fetched (5xx or network error)
+}
+
+type robotsRule struct {
+	allow   bool
+	pattern string // May contain "*" (any characters) and end with "$" (end of the path)
+}
+
+// parseRobots reads a robots.txt and keeps the group for agent,
+// or the "*" group when no group names agent.
+// A group names agent when its User-agent value equals it, ignoring case (RFC 9309 §2.2.1).
+func parseRobots(r io.Reader, agent string) *robots {
+	var (
+		own, any robots
+		sawOwn   bool
+		current  []*robots // The groups the lines being read belong to
+		inRules  bool      // The current group has started listing rules
+	)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		if key == "user-agent" {
+			// A user-agent line after rules starts a new group
+			if inRules {
+				current, inRules = nil, false
+			}
+			switch {
+			case value == "*":
+				current = append(current, &any)
+			case value != "" && strings.EqualFold(value, agent):
+				current = append(current, &own)
+				sawOwn = true
+			}
+			continue
+		}
+
+		inRules = true
+		for _, g := range current {
+			switch key {
+			case "allow", "disallow":
+				// An empty Disallow means "allow everything" and adds no rule
+				if value != "" {
+					g.rules = append(g.rules, robotsRule{allow: key == "allow", pattern: value})
+				}
+			case "crawl-delay":
+				if secs, err := strconv.ParseFloat(value, 64); err == nil && secs > 0 {
+					g.crawlDelay = time.Duration(secs * float64(time.Second))
+				}
+			}
+		}
+	}
+
+	if sawOwn {
+		return &own
+	}
+	return &any
+}
+
+// allowed reports whether path (with its query, e.g. "/a?b=1") may be fetched
+func (r *robots) allowed(path string) bool {
+	if r.disallowAll {
+		return false
+	}
+	// robots.txt itself is always allowed
+	if path == "/robots.txt" {
+		return true
+	}
+
+	best, allow := -1, true
+	for _, rule := range r.rules {
+		if !matchRobots(rule.pattern, path) {
+			continue
+		}
+		n := len(rule.pattern)
+		if n > best || (n == best && rule.allow) {
+			best, allow = n, rule.allow
+		}
+	}
+	return allow
+}
+
+// matchRobots reports whether path starts with pattern, where "*" matches any characters
+// and a trailing "$" anchors the pattern to the end of path.
+func matchRobots(pattern, path string) bool {
+	anchored := strings.HasSuffix(pattern, "$")
+	pattern = strings.TrimSuffix(pattern, "$")
+
+	parts := strings.Split(pattern, "*")
+	// The first part must be a prefix
+	if !strings.HasPrefix(path, parts[0]) {
+		return false
+	}
+	path = path[len(parts[0]):]
+
+	for i, part := range parts[1:] {
+		// An anchored last part must match the very end; earlier parts match as early as possible
+		if anchored && i == len(parts)-2 {
+			return strings.HasSuffix(path, part)
+		}
+		j := strings.Index(path, part)
+		if j < 0 {
+			return false
+		}
+		path = path[j+len(part):]
+	}
+	// Without "*", an anchored pattern must have consumed the whole path
+	return !anchored || path == ""
+}
@@ -0,0 +1,101 @@
+package crawl
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseRobotsAgent(t *testing.T) {
+	const txt = `
+User-agent: *
+Disallow: /all
+
+User-agent: url
+Disallow: /url
+
+User-agent:
+Disallow: /blank
+
+User-agent: URL-Checker
+User-agent: other-bot
+Disallow: /ours
+Crawl-delay: 1.5
+`
+	tests := []struct {
+		agent    string
+		disallow string // The one path the chosen group disallows
+		delay    time.Duration
+	}{
+		{"url-checker", "/ours", 1500 * time.Millisecond},
+		{"Url-Checker", "/ours", 1500 * time.Millisecond},
+		{"other-bot", "/ours", 1500 * time.Millisecond},
+		{"url", "/url", 0},
+		// Neither "url" nor the blank group is a match just because it is part of the name
+		{"url-checker-ng", "/all", 0},
+		{"curl", "/all", 0},
+	}
+	for _, tt := range tests {
+		r := parseRobots(strings.NewReader(txt), tt.agent)
+		for _, path := range []string{"/all", "/url", "/blank", "/ours"} {
+			if got, want := r.allowed(path), path != tt.disallow; got != want {
+				t.Errorf("%s: allowed(%s) = %v, want %v", tt.agent, path, got, want)
+			}
+		}
+		if r.crawlDelay != tt.delay {
+			t.Errorf("%s: crawl delay %v, want %v", tt.agent, r.crawlDelay, tt.delay)
+		}
+	}
+}
+
+func TestRobotsAllowed(t *testing.T) {
+	const txt = `
+User-agent: *
+Disallow: /private
+Allow: /private/public
+Disallow: /*.pdf$
+Disallow: /tmp/*/cache
+Allow: /same
+Disallow: /same
+Disallow:
+`
+	r := parseRobots(strings.NewReader(txt), "url-checker")
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"/", true},
+		{"/private", false},
+		{"/private/secret", false},
+		{"/private/public/page", true}, // the longer Allow wins
+		{"/doc.pdf", false},
+		{"/doc.pdf?download=1", true}, // "$" anchors to the end
+		{"/docs/a.pdf", false},
+		{"/tmp/x/cache/file", false},
+		{"/tmp/cache", true},
+		{"/same", true}, // Allow wins a tie
+		{"/robots.txt", true},
+	}
+	for _, tt := range tests {
+		if got := r.allowed(tt.path); got != tt.want {
+			t.Errorf("allowed(%s) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+
+	if (&robots{disallowAll: true}).allowed("/") {
+		t.Error("an unreachable robots.txt must disallow everything")
+	}
+}
+
+func TestAgentToken(t *testing.T) {
+	for ua, want := range map[string]string{
+		"url-checker/1.0 (+https://example.com)": "url-checker",
+		"mirror bot":                             "mirror",
+		"plain":                                  "plain",
+		"":                                       "",
+	} {
+		if got := agentToken(ua); got != want {
+			t.Errorf("agentToken(%q) = %q, want %q", ua, got, want)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"master_go_programming/50_project_url_checker_page_downloader/content"
+	"master_go_programming/50_project_url_checker_page_downloader/crawl"
+	"master_go_programming/50_project_url_checker_page_downloader/retry"
+)
+
+// errDisallowed explains why a crawled URL was skipped
+var errDisallowed = errors.New("disallowed by robots.txt")
+
+// runCrawl implements "crawl [flags] url ...": mirror the sites of the given pages into -o
+// so they can be browsed offline. It returns the exit status.
+func runCrawl(args []string) int {
+	fs := flag.NewFlagSet("crawl", flag.ExitOnError)
+	depth := fs.Int("depth", 2, "link hops followed from the start pages (0 = only the start pages)")
+	concurrency := fs.Int("c", 4, "number of requests in flight at once")
+	delay := fs.Duration("delay", 500*time.Millisecond, "minimum time between two requests to the same host")
+	timeout := fs.Duration("timeout", 30*time.Second, "time limit for each attempt, including reading the body")
+	retries := fs.Int("retries", 2, "extra attempts for temporary failures")
+	outDir := fs.String("o", "mirror", "directory for the mirrored pages and their manifest.json")
+	userAgent := fs.String("user-agent", crawl.DefaultUserAgent, "User-Agent header, also matched against robots.txt")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: %s crawl [flags] url ...\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	var urls []string
+	for _, s := range fs.Args() {
+		u, err := normalizeURL(s)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 2
+		}
+		urls = append(urls, u)
+	}
+	if len(urls) == 0 {
+		fs.Usage()
+		return 2
+	}
+
+	store, err := content.Open(*outDir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	// Ctrl-C stops the crawl; the pages saved so far are still rewritten and listed
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	policy := retry.Default()
+	policy.MaxAttempts = *retries + 1
+	pages, err := crawl.Crawl(ctx, urls, store, crawl.Options{
+		MaxDepth:    *depth,
+		Concurrency: *concurrency,
+		Delay:       *delay,
+		UserAgent:   *userAgent,
+		Client:      &http.Client{Timeout: *timeout},
+		Policy:      policy,
+		OnPage: func(p crawl.Page) {
+			fmt.Fprintf(os.Stderr, "depth %d  %s\n", p.Depth, p.URL)
+		},
+	})
+	if flushErr := store.Flush(); flushErr != nil {
+		fmt.Fprintln(os.Stderr, "writing manifest:", flushErr)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	}
+
+	results := make([]result, len(pages))
+	for i, p := range pages {
+		results[i] = result{url: p.URL, statusCode: p.Status, attempts: p.Attempts, elapsed: p.Elapsed, err: p.Err}
+		if p.File != "" {
+			results[i].file = filepath.Join(*outDir, filepath.FromSlash(p.File))
+		}
+		if p.Disallowed {
+			results[i].skipped = true
+			results[i].err = errDisallowed
+		}
+		// Leaving the site is not a failure of the site
+		if errors.Is(p.Err, crawl.ErrOffsiteRedirect) {
+			results[i].skipped = true
+		}
+	}
+	printSummary(os.Stdout, results)
+
+	switch {
+	case ctx.Err() != nil:
+		return exitInterrupted
+	case err != nil:
+		return 1
+	}
+	for _, r := range results {
+		if !r.skipped && !r.Up() {
+			return 1
+		}
+	}
+	return 0
+}
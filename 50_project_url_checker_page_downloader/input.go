@@ -0,0 +1,75 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// readURLs collects the URLs to check: the arguments first, then the lines of file.
+// file "-" means stdin; when there are no arguments and no file, stdin is read as well.
+// Every URL is validated, and "example.com" is accepted as "https://example.com".
+// A URL given twice, in either form, is checked once, where it first appears.
+func readURLs(args []string, file string, stdin io.Reader) ([]string, error) {
+	raw := append([]string(nil), args...)
+
+	var r io.Reader
+	switch {
+	case file == "-" || (file == "" && len(args) == 0):
+		r = stdin
+	case file != "":
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	if r != nil {
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			// Skip blank lines and comments
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			raw = append(raw, line)
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	urls := make([]string, 0, len(raw))
+	seen := make(map[string]bool, len(raw))
+	for _, s := range raw {
+		u, err := normalizeURL(s)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[u] {
+			seen[u] = true
+			urls = append(urls, u)
+		}
+	}
+	return urls, nil
+}
+
+// normalizeURL adds https:// when the scheme is missing and rejects anything that is not http(s)
+func normalizeURL(s string) (string, error) {
+	if !strings.Contains(s, "://") {
+		s = "https://" + s
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		return "", fmt.Errorf("invalid URL %q: %w", s, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("invalid URL %q: need an http or https URL with a host", s)
+	}
+	return u.String(), nil
+}
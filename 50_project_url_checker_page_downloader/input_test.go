@@ -0,0 +1,51 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestReadURLs(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "urls.txt")
+	err := os.WriteFile(file, []byte("# list\nhttps://go.dev\n\nexample.org\nhttps://example.org\nhttp://go.dev\n"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		args  []string
+		file  string
+		stdin string
+		want  []string
+	}{
+		{"args", []string{"go.dev", "https://go.dev", "example.com", "go.dev"}, "", "",
+			[]string{"https://go.dev", "https://example.com"}},
+		{"args and file", []string{"example.org", "golang.org"}, file, "",
+			[]string{"https://example.org", "https://golang.org", "https://go.dev", "http://go.dev"}},
+		{"args and stdin", []string{"go.dev"}, "-", "example.com\ngo.dev\nhttps://example.com\n",
+			[]string{"https://go.dev", "https://example.com"}},
+		{"stdin only", nil, "", "b.example\na.example\nb.example\n",
+			[]string{"https://b.example", "https://a.example"}},
+	}
+	for _, tt := range tests {
+		got, err := readURLs(tt.args, tt.file, strings.NewReader(tt.stdin))
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: readURLs = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestReadURLsInvalid(t *testing.T) {
+	for _, s := range []string{"ftp://go.dev", "https://", "http://[::1"} {
+		if _, err := readURLs([]string{s}, "", nil); err == nil {
+			t.Errorf("readURLs(%q) accepted it", s)
+		}
+	}
+}
@@ -1,82 +1,171 @@
+// 50_project_url_checker_page_downloader checks whether web pages are reachable
+// and saves the body of every page that answers 200 OK.
+//
+// Run:
+//
+//	go run ./50_project_url_checker_page_downloader https://go.dev https://www.google1.com
+//	go run ./50_project_url_checker_page_downloader -f urls.txt -c 20 -timeout 5s -retries 4 -o pages
+//	cat urls.txt | go run ./50_project_url_checker_page_downloader
+//
+// Pages are saved below -o as <host>/<path>-<hash>.<ext>, and -o/manifest.json records
+// the URL, file, status, size, content type and fetch time of each one (see package content).
+//
+// The manifest also keeps each page's ETag and Last-Modified, so the next run sends a
+// conditional request and a page that has not changed (304) is reported as "unchanged"
+// instead of being downloaded again. A download that breaks off is kept in -o/.partial
+// and continued with a Range request, by a retry or by the next run, when the server allows it.
+//
+// URLs come from the arguments, from the file named by -f ("-" = stdin),
+// or from stdin when neither is given. In files, blank lines and lines starting with # are ignored.
+//
+// Exit status: 0 when every URL is UP, 1 when any URL is DOWN, 2 for invalid usage,
+// so the command can be used directly in cron jobs and CI smoke checks.
+//
+// Requests that fail for a temporary reason (timeouts, refused connections, 429, 502, 503, 504)
+// are retried with exponential backoff and jitter, honouring the server's Retry-After.
+//
+// Ctrl-C stops starting new checks and lets the ones in flight finish;
+// a second Ctrl-C cancels them too. The URLs never checked are reported as SKIPPED
+// and the exit status is 130. -deadline cancels the whole run the same way after a fixed time.
+//
+// "crawl" mirrors whole sites instead: it follows the links of the given pages within
+// the same host up to -depth, honours robots.txt and a per-host -delay, and rewrites
+// the links of the saved pages so the copy can be browsed offline:
+//
+//	go run ./50_project_url_checker_page_downloader crawl -depth 2 -o mirror https://example.com
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
-	"io/ioutil"
-	"log"
 	"net/http"
-	"runtime"
-	"strings"
-	"sync"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"master_go_programming/50_project_url_checker_page_downloader/content"
+	"master_go_programming/50_project_url_checker_page_downloader/pool"
+	"master_go_programming/50_project_url_checker_page_downloader/retry"
 )
 
-// checkAndSaveBody checks if a given URL is reachable.
-// If the response is 200 (OK), it saves the response body to a text file.
-// After finishing, it signals the WaitGroup that this goroutine is done.
-func checkAndSaveBody(url string, wg *sync.WaitGroup) {
+// exitInterrupted is the conventional exit status after Ctrl-C (128 + SIGINT)
+const exitInterrupted = 130
+
+func main() {
+	if len(os.Args) > 1 && os.Args[1] == "crawl" {
+		os.Exit(runCrawl(os.Args[2:]))
+	}
 
-	// Attempt to send GET request to the URL
-	resp, err := http.Get(url)
+	file := flag.String("f", "", `file with one URL per line ("-" = stdin)`)
+	concurrency := flag.Int("c", 10, "number of URLs checked at the same time")
+	timeout := flag.Duration("timeout", 10*time.Second, "time limit for each attempt, including reading the body")
+	retries := flag.Int("retries", 2, "extra attempts for temporary failures")
+	backoff := flag.Duration("backoff", 500*time.Millisecond, "upper bound of the first wait between attempts; it doubles every retry")
+	deadline := flag.Duration("deadline", 0, "time limit for the whole run (0 = none)")
+	outDir := flag.String("o", "pages", `directory for the saved pages and their manifest.json ("" = do not save)`)
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: %s [flags] [url ...]\n       %s crawl [flags] url ...\n", os.Args[0], os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	urls, err := readURLs(flag.Args(), *file, os.Stdin)
 	if err != nil {
-		fmt.Printf("%s is DOWN!\n", url)
-	} else {
-		// Print the HTTP status code
-		fmt.Printf("Status Code: %d  ", resp.StatusCode)
-
-		// Only save response body if the server returned 200 OK
-		if resp.StatusCode == 200 {
-			bodyBytes, err := ioutil.ReadAll(resp.Body)
-			if err != nil {
-				log.Fatal(err)
-			}
-
-			// Generate filename based on domain (after //) + ".txt"
-			file := strings.Split(url, "//")[1] + ".txt"
-
-			fmt.Printf("Writing response Body to %s\n", file)
-
-			// Save body to local file
-			err = ioutil.WriteFile(file, bodyBytes, 0664)
-			if err != nil {
-				log.Fatal(err)
-			}
-		}
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	if len(urls) == 0 {
+		fmt.Fprintln(os.Stderr, "no URLs to check")
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *concurrency < 1 {
+		fmt.Fprintln(os.Stderr, "-c must be at least 1")
+		os.Exit(2)
 	}
 
-	// Mark this goroutine as finished
-	wg.Done()
-}
+	// requestCtx cancels the requests in flight; stopCtx only stops new ones from starting
+	requestCtx, abort := context.WithCancel(context.Background())
+	defer abort()
+	if *deadline > 0 {
+		requestCtx, abort = context.WithTimeout(requestCtx, *deadline)
+		defer abort()
+	}
+	stopCtx, stop := context.WithCancel(requestCtx)
+	defer stop()
+	interrupted := handleSignals(stop, abort)
 
-func main() {
-	// List of URLs to check
-	urls := []string{
-		"https://www.golang.org",
-		"https://www.google1.com", // intentionally invalid
-		"https://www.medium.com",
+	policy := retry.Default()
+	policy.MaxAttempts = *retries + 1
+	policy.BaseDelay = *backoff
+	c := &checker{
+		client: &http.Client{Timeout: *timeout},
+		policy: policy,
+	}
+	if *outDir != "" {
+		if c.store, err = content.Open(*outDir); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
 	}
 
-	// 1. Create a WaitGroup to synchronize goroutines
-	var wg sync.WaitGroup
+	results := checkAll(stopCtx, requestCtx, urls, *concurrency, c)
+	printSummary(os.Stdout, results)
 
-	// 2. Tell the WaitGroup how many goroutines to wait for
-	wg.Add(len(urls))
+	if c.store != nil {
+		if err := c.store.Flush(); err != nil {
+			fmt.Fprintln(os.Stderr, "writing manifest:", err)
+		}
+	}
 
-	// 3. Launch one goroutine per URL
-	for _, url := range urls {
-		go checkAndSaveBody(url, &wg)
+	select {
+	case <-interrupted:
+		os.Exit(exitInterrupted)
+	default:
 	}
+	// Any DOWN URL fails the run
+	for _, r := range results {
+		if !r.Up() {
+			os.Exit(1)
+		}
+	}
+}
 
-	// Print current number of goroutines (main + 3 workers)
-	fmt.Println("No. of Goroutines:", runtime.NumGoroutine())
+// handleSignals calls stop on the first Ctrl-C (or SIGTERM) and abort on the second.
+// The returned channel is closed once the first signal has arrived.
+func handleSignals(stop, abort context.CancelFunc) <-chan struct{} {
+	interrupted := make(chan struct{})
+	sigs := make(chan os.Signal, 2)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
 
-	// 4. Block main() until all goroutines call wg.Done()
-	wg.Wait()
+	go func() {
+		<-sigs
+		close(interrupted)
+		fmt.Fprintln(os.Stderr, "interrupted: waiting for the requests in flight (Ctrl-C again to cancel them)")
+		stop()
+
+		<-sigs
+		fmt.Fprintln(os.Stderr, "cancelling the requests in flight")
+		abort()
+	}()
+	return interrupted
 }
 
-// Run: go run main.go
-//
-// **EXPECTED OUTPUT:**
-// No. of Goroutines: 4
-// https://www.google1.com is DOWN!
-// Status Code: 200  Writing response Body to www.golang.org.txt
-// Status Code: 200  Writing response Body to www.medium.com.txt
+// checkAll checks every URL with at most concurrency requests in flight
+// and returns the results in the same order as urls.
+// No new check starts once stopCtx is done; the requests in flight are bound to requestCtx.
+func checkAll(stopCtx, requestCtx context.Context, urls []string, concurrency int, c *checker) []result {
+	results := make([]result, len(urls))
+
+	started := pool.Run(stopCtx, concurrency, len(urls), func(i int) {
+		results[i] = c.checkAndSaveBody(requestCtx, urls[i])
+	})
+
+	// Report the URLs the pool never got to
+	for i := started; i < len(urls); i++ {
+		results[i] = result{url: urls[i], skipped: true, err: context.Cause(stopCtx)}
+	}
+	return results
+}
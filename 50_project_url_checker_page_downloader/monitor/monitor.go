@@ -0,0 +1,199 @@
+package monitor
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"master_go_programming/50_project_url_checker_page_downloader/retry"
+)
+
+// Options configures a Monitor. Only Interval is required.
+type Options struct {
+	Interval time.Duration   // Time between two checks of the same URL
+	Windows  []time.Duration // Sliding windows for Stats; nil = 1 hour and 24 hours
+	Client   *http.Client    // nil = a client with a 10 second timeout
+	Policy   retry.Policy    // Retries within one check; the zero value never retries
+	Store    *Store          // Where every probe is appended; nil = keep probes in memory only
+	Notifier Notifier        // Who hears about UP/DOWN changes; nil = nobody
+	OnProbe  func(Probe)     // Called after every probe is recorded, e.g. to print it; may be nil
+	Log      *log.Logger     // Where store and notifier errors go; nil = log.Default()
+}
+
+// Monitor checks a fixed list of URLs forever (until its context is cancelled).
+//
+// Every URL has its own goroutine that checks it every Interval and sends the Probe
+// over a channel; Run receives them one by one, so recording needs no extra goroutines
+// and the probes of one URL are always recorded in order.
+type Monitor struct {
+	urls []string
+	opts Options
+
+	mu      sync.Mutex
+	history *History
+	state   map[string]string // Last known "UP"/"DOWN" per URL
+
+	subscribers map[chan Status]struct{} // See Subscribe
+}
+
+// New creates a monitor for urls. Call Load to continue from earlier probes, then Run.
+func New(urls []string, opts Options) *Monitor {
+	if len(opts.Windows) == 0 {
+		opts.Windows = []time.Duration{time.Hour, 24 * time.Hour}
+	}
+	if opts.Client == nil {
+		opts.Client = &http.Client{Timeout: 10 * time.Second}
+	}
+	if opts.Log == nil {
+		opts.Log = log.Default()
+	}
+	return &Monitor{
+		urls:    slices.Clone(urls),
+		opts:    opts,
+		history: NewHistory(slices.Max(opts.Windows)),
+		state:   make(map[string]string),
+
+		subscribers: make(map[chan Status]struct{}),
+	}
+}
+
+// Load adds earlier probes (e.g. from LoadProbes) to the history without storing or alerting,
+// so uptime and the UP/DOWN state survive a restart. Probes of other URLs are ignored.
+func (m *Monitor) Load(probes []Probe) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, p := range probes {
+		if !slices.Contains(m.urls, p.URL) {
+			continue
+		}
+		m.history.Add(p)
+		m.state[p.URL] = p.State()
+	}
+}
+
+// Run checks every URL immediately and then every Interval until ctx is cancelled.
+// It returns ctx.Err() once all its goroutines have stopped.
+// Run can be called again afterwards; the history and UP/DOWN state carry over.
+func (m *Monitor) Run(ctx context.Context) error {
+	var wg sync.WaitGroup
+
+	// Alerts are delivered in order by a single goroutine,
+	// so a slow webhook cannot delay probes. Every Run has its own queue.
+	alerts := make(chan Alert, 64)
+	alertsDone := make(chan struct{})
+	go func() {
+		defer close(alertsDone)
+		m.deliverAlerts(ctx, alerts)
+	}()
+
+	// One goroutine per URL sends its probes into c
+	c := make(chan Probe)
+	for _, url := range m.urls {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m.probeEvery(ctx, url, c)
+		}()
+	}
+
+	// Close c once every prober has stopped, which ends the loop below
+	go func() {
+		wg.Wait()
+		close(c)
+	}()
+
+	for p := range c {
+		m.record(p, alerts)
+	}
+	close(alerts)
+	<-alertsDone
+	return ctx.Err()
+}
+
+// probeEvery checks url every Interval and sends each probe into c until ctx is done.
+func (m *Monitor) probeEvery(ctx context.Context, url string, c chan<- Probe) {
+	ticker := time.NewTicker(m.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		p := Check(ctx, m.opts.Client, m.opts.Policy, url)
+		// A probe cut short by shutdown says nothing about the URL
+		if ctx.Err() != nil {
+			return
+		}
+		select {
+		case c <- p:
+		case <-ctx.Done():
+			return
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// record stores p, adds it to the history and queues an alert in alerts if the URL changed state.
+func (m *Monitor) record(p Probe, alerts chan<- Alert) {
+	if m.opts.Store != nil {
+		if err := m.opts.Store.Append(p); err != nil {
+			m.opts.Log.Printf("monitor: storing probe of %s: %v", p.URL, err)
+		}
+	}
+
+	m.mu.Lock()
+	m.history.Add(p)
+	from := m.state[p.URL]
+	m.state[p.URL] = p.State()
+	m.publishLocked(p.URL, time.Now())
+	m.mu.Unlock()
+
+	if m.opts.OnProbe != nil {
+		m.opts.OnProbe(p)
+	}
+
+	// Alert on every change, and when a URL is DOWN from the very first probe
+	if from != p.State() && (from != "" || !p.Up) {
+		a := Alert{URL: p.URL, From: from, To: p.State(), Probe: p}
+		select {
+		case alerts <- a:
+		default:
+			m.opts.Log.Printf("monitor: alert queue full, dropping: %s", a)
+		}
+	}
+}
+
+// deliverAlerts sends every alert of the queue to the notifier until the queue is closed.
+func (m *Monitor) deliverAlerts(ctx context.Context, alerts <-chan Alert) {
+	for a := range alerts {
+		if m.opts.Notifier == nil {
+			continue
+		}
+		// Alerts still queued at shutdown get a short grace period of their own
+		nctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+		if err := m.opts.Notifier.Notify(nctx, a); err != nil {
+			m.opts.Log.Printf("monitor: sending alert for %s: %v", a.URL, err)
+		}
+		cancel()
+	}
+}
+
+// Stats returns the stats of every URL over every window, ending at now,
+// grouped by URL in the order the URLs were given.
+func (m *Monitor) Stats(now time.Time) []Stats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats := make([]Stats, 0, len(m.urls)*len(m.opts.Windows))
+	for _, url := range m.urls {
+		for _, w := range m.opts.Windows {
+			stats = append(stats, m.history.Stats(url, w, now))
+		}
+	}
+	return stats
+}
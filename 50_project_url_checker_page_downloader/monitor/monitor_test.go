@@ -0,0 +1,64 @@
+package monitor
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestRunTwice runs one monitor twice: the second Run must not panic on the alert queue
+// of the first, and the UP/DOWN state carries over between the two.
+func TestRunTwice(t *testing.T) {
+	var up atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !up.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	var mu sync.Mutex
+	var alerts []string
+	var cancel context.CancelFunc
+	m := New([]string{srv.URL}, Options{
+		Interval: time.Hour,
+		Client:   srv.Client(),
+		Notifier: NotifierFunc(func(_ context.Context, a Alert) error {
+			mu.Lock()
+			defer mu.Unlock()
+			alerts = append(alerts, a.From+"->"+a.To)
+			return nil
+		}),
+		// One probe per Run is enough
+		OnProbe: func(Probe) { cancel() },
+		Log:     log.New(io.Discard, "", 0),
+	})
+
+	for _, state := range []bool{false, true} {
+		up.Store(state)
+		ctx, stop := context.WithCancel(context.Background())
+		cancel = stop
+		err := m.Run(ctx)
+		stop()
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("Run = %v, want context.Canceled", err)
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if want := []string{"->DOWN", "DOWN->UP"}; !slices.Equal(alerts, want) {
+		t.Fatalf("alerts = %q, want %q", alerts, want)
+	}
+	if s := m.Stats(time.Now()); len(s) == 0 || s[0].Probes != 2 {
+		t.Fatalf("Stats = %+v, want 2 probes", s)
+	}
+}
@@ -0,0 +1,141 @@
+package monitor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// Alert says that a URL changed from UP to DOWN or back
+type Alert struct {
+	URL   string `json:"url"`
+	From  string `json:"from"` // "UP", "DOWN", or "" for the first probe of a URL
+	To    string `json:"to"`
+	Probe Probe  `json:"probe"` // The probe that showed the change
+}
+
+// String returns a one-line description, e.g. "https://go.dev is DOWN (was UP): 503"
+func (a Alert) String() string {
+	s := fmt.Sprintf("%s is %s", a.URL, a.To)
+	if a.From != "" {
+		s += fmt.Sprintf(" (was %s)", a.From)
+	}
+	switch {
+	case a.Probe.Error != "":
+		s += ": " + a.Probe.Error
+	case a.Probe.StatusCode != 0:
+		s += fmt.Sprintf(": %d", a.Probe.StatusCode)
+	}
+	return s
+}
+
+// Notifier delivers alerts somewhere: a terminal, a file, a chat webhook, ...
+type Notifier interface {
+	Notify(ctx context.Context, a Alert) error
+}
+
+// NotifierFunc lets an ordinary function be used as a Notifier
+type NotifierFunc func(ctx context.Context, a Alert) error
+
+// Notify calls f(ctx, a)
+func (f NotifierFunc) Notify(ctx context.Context, a Alert) error {
+	return f(ctx, a)
+}
+
+// WriterNotifier prints one line per alert to W, e.g. os.Stdout.
+type WriterNotifier struct {
+	W io.Writer
+}
+
+// Notify prints a with a timestamp
+func (n WriterNotifier) Notify(_ context.Context, a Alert) error {
+	_, err := fmt.Fprintf(n.W, "%s ALERT %s\n", a.Probe.Time.Format(time.DateTime), a)
+	return err
+}
+
+// FileNotifier appends every alert as a JSON line to the file at Path.
+type FileNotifier struct {
+	Path string
+	mu   sync.Mutex
+}
+
+// Notify appends a to the file, creating it if needed
+func (n *FileNotifier) Notify(_ context.Context, a Alert) error {
+	line, err := json.Marshal(a)
+	if err != nil {
+		return err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	f, err := os.OpenFile(n.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0664)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// WebhookNotifier POSTs every alert as JSON to URL.
+// Besides the Alert fields, the body has a "text" field with Alert.String(),
+// which Slack, Mattermost and similar incoming webhooks display as the message.
+type WebhookNotifier struct {
+	URL    string
+	Client *http.Client // nil = a client with a 10 second timeout
+}
+
+// Notify posts a and fails unless the webhook answers with a 2xx status
+func (n WebhookNotifier) Notify(ctx context.Context, a Alert) error {
+	body, err := json.Marshal(struct {
+		Alert
+		Text string `json:"text"`
+	}{a, a.String()})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := n.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook %s answered %s", n.URL, resp.Status)
+	}
+	return nil
+}
+
+// MultiNotifier sends every alert to all of its notifiers, even when some of them fail.
+type MultiNotifier []Notifier
+
+// Notify calls every notifier in order and joins their errors
+func (m MultiNotifier) Notify(ctx context.Context, a Alert) error {
+	var errs []error
+	for _, n := range m {
+		if err := n.Notify(ctx, a); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
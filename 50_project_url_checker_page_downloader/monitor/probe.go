@@ -0,0 +1,64 @@
+// Package monitor checks URLs at a fixed interval, keeps every result in an append-only
+// JSON Lines file, computes uptime and latency percentiles over sliding windows,
+// and sends alerts when a URL goes from UP to DOWN or back.
+package monitor
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"master_go_programming/50_project_url_checker_page_downloader/retry"
+)
+
+// Probe is the result of checking one URL once
+type Probe struct {
+	URL        string        `json:"url"`
+	Time       time.Time     `json:"time"`               // When the check started
+	Up         bool          `json:"up"`                 // 2xx or 3xx answer
+	StatusCode int           `json:"status,omitempty"`   // 0 when no response arrived
+	Latency    time.Duration `json:"latency_ns"`         // Until the whole body was read, retries included
+	Attempts   int           `json:"attempts,omitempty"` // Requests sent, retries included
+	Error      string        `json:"error,omitempty"`
+}
+
+// State returns "UP" or "DOWN"
+func (p Probe) State() string {
+	if p.Up {
+		return "UP"
+	}
+	return "DOWN"
+}
+
+// Check sends a GET request to url (retrying temporary failures according to policy),
+// reads the whole body and returns what happened.
+func Check(ctx context.Context, client *http.Client, policy retry.Policy, url string) Probe {
+	p := Probe{URL: url, Time: time.Now()}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		p.Error = err.Error()
+		return p
+	}
+
+	resp, attempts, err := policy.Do(ctx, client, req)
+	p.Attempts = attempts
+	if err != nil {
+		p.Latency = time.Since(p.Time)
+		p.Error = err.Error()
+		return p
+	}
+	defer resp.Body.Close()
+
+	// The latency includes the body: a page whose headers are fast but whose body stalls is slow
+	_, err = io.Copy(io.Discard, resp.Body)
+	p.Latency = time.Since(p.Time)
+	p.StatusCode = resp.StatusCode
+	if err != nil {
+		p.Error = err.Error()
+		return p
+	}
+	p.Up = resp.StatusCode >= 200 && resp.StatusCode < 400
+	return p
+}
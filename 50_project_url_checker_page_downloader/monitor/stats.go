@@ -0,0 +1,101 @@
+package monitor
+
+import (
+	"slices"
+	"time"
+
+	"master_go_programming/56_data_structure_algorithm/data"
+)
+
+// Stats summarizes the probes of one URL over one window of time
+type Stats struct {
+	URL    string        `json:"url"`
+	Window time.Duration `json:"window_ns"`
+	Probes int           `json:"probes"`
+	Up     int           `json:"up"`
+	Uptime float64       `json:"uptime"` // Percentage of probes that were UP, 0 when there are none
+	P50    time.Duration `json:"p50_ns"` // Latency percentiles over the probes that got a response
+	P95    time.Duration `json:"p95_ns"`
+	P99    time.Duration `json:"p99_ns"`
+}
+
+// History keeps the probes of each URL for the last maxAge, oldest first.
+// It is not safe for concurrent use; Monitor guards it with its own lock.
+type History struct {
+	maxAge time.Duration
+	probes map[string][]Probe
+}
+
+// NewHistory creates a history that forgets probes older than maxAge
+// (measured from the newest probe added).
+func NewHistory(maxAge time.Duration) *History {
+	return &History{maxAge: maxAge, probes: make(map[string][]Probe)}
+}
+
+// Add records p. Probes must be added in time order per URL, as the monitor does.
+func (h *History) Add(p Probe) {
+	list := append(h.probes[p.URL], p)
+
+	// Drop the probes that fell out of the largest window
+	cutoff := p.Time.Add(-h.maxAge)
+	if i := data.Search(list, func(q Probe) bool { return !q.Time.Before(cutoff) }); i > 0 {
+		// Copy instead of re-slicing so the dropped probes do not pin the old array forever
+		list = slices.Clone(list[i:])
+	}
+	h.probes[p.URL] = list
+}
+
+// Probes returns the probes of url at or after since, oldest first.
+// The returned slice must not be modified.
+func (h *History) Probes(url string, since time.Time) []Probe {
+	list := h.probes[url]
+	i := data.Search(list, func(q Probe) bool { return !q.Time.Before(since) })
+	return list[i:]
+}
+
+// Last returns the newest probe of url; ok is false if there is none.
+func (h *History) Last(url string) (p Probe, ok bool) {
+	list := h.probes[url]
+	if len(list) == 0 {
+		return p, false
+	}
+	return list[len(list)-1], true
+}
+
+// Stats computes uptime and latency percentiles of url over the window ending at now.
+func (h *History) Stats(url string, window time.Duration, now time.Time) Stats {
+	s := Stats{URL: url, Window: window}
+	probes := h.Probes(url, now.Add(-window))
+	s.Probes = len(probes)
+
+	var latencies []time.Duration
+	for _, p := range probes {
+		if p.Up {
+			s.Up++
+		}
+		// A failed connection has no meaningful latency, but a slow 503 does
+		if p.StatusCode != 0 {
+			latencies = append(latencies, p.Latency)
+		}
+	}
+	if s.Probes > 0 {
+		s.Uptime = 100 * float64(s.Up) / float64(s.Probes)
+	}
+
+	slices.Sort(latencies)
+	s.P50 = percentile(latencies, 50)
+	s.P95 = percentile(latencies, 95)
+	s.P99 = percentile(latencies, 99)
+	return s
+}
+
+// percentile returns the p-th percentile of sorted using the nearest-rank method:
+// the smallest value that is greater than or equal to p percent of the values.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	// rank = ceil(p/100 · n), 1-based
+	rank := (p*len(sorted) + 99) / 100
+	return sorted[max(rank, 1)-1]
+}
@@ -0,0 +1,93 @@
+package monitor
+
+import (
+	"time"
+)
+
+// sparklineSize is how many recent latencies Status carries for drawing a sparkline
+const sparklineSize = 60
+
+// Status is the current picture of one URL, as shown on a status page
+type Status struct {
+	URL         string          `json:"url"`
+	State       string          `json:"state"`                 // "UP", "DOWN", or "UNKNOWN" before the first probe
+	LastChecked time.Time       `json:"last_checked,omitzero"` // Time of the last probe
+	LastProbe   *Probe          `json:"last_probe,omitempty"`
+	Stats       []Stats         `json:"stats"`        // One entry per window
+	Latencies   []time.Duration `json:"latencies_ns"` // Up to the last 60 latencies, oldest first; 0 = no response
+}
+
+// Status returns the status of every URL at now, in the order the URLs were given.
+func (m *Monitor) Status(now time.Time) []Status {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	statuses := make([]Status, 0, len(m.urls))
+	for _, url := range m.urls {
+		statuses = append(statuses, m.statusLocked(url, now))
+	}
+	return statuses
+}
+
+// statusLocked builds the status of url; m.mu must be held.
+func (m *Monitor) statusLocked(url string, now time.Time) Status {
+	s := Status{URL: url, State: "UNKNOWN"}
+	if p, ok := m.history.Last(url); ok {
+		s.State = p.State()
+		s.LastChecked = p.Time
+		s.LastProbe = &p
+	}
+
+	for _, w := range m.opts.Windows {
+		s.Stats = append(s.Stats, m.history.Stats(url, w, now))
+	}
+
+	// The last sparklineSize probes, whatever window they fall in
+	probes := m.history.Probes(url, time.Time{})
+	probes = probes[max(len(probes)-sparklineSize, 0):]
+	s.Latencies = make([]time.Duration, len(probes))
+	for i, p := range probes {
+		if p.StatusCode != 0 {
+			s.Latencies[i] = p.Latency
+		}
+	}
+	return s
+}
+
+// Subscribe returns a channel that receives the new Status of a URL after each of its probes,
+// and a function that ends the subscription and closes the channel.
+//
+// ⚠️ Updates are dropped for a subscriber that falls more than 16 updates behind,
+// so a stuck reader can never stall the monitor.
+func (m *Monitor) Subscribe() (<-chan Status, func()) {
+	c := make(chan Status, 16)
+
+	m.mu.Lock()
+	m.subscribers[c] = struct{}{}
+	m.mu.Unlock()
+
+	unsubscribe := func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		// Only the first call closes the channel
+		if _, ok := m.subscribers[c]; ok {
+			delete(m.subscribers, c)
+			close(c)
+		}
+	}
+	return c, unsubscribe
+}
+
+// publishLocked sends the status of url to every subscriber; m.mu must be held.
+func (m *Monitor) publishLocked(url string, now time.Time) {
+	if len(m.subscribers) == 0 {
+		return
+	}
+	s := m.statusLocked(url, now)
+	for c := range m.subscribers {
+		select {
+		case c <- s:
+		default:
+		}
+	}
+}
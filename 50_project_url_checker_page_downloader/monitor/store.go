@@ -0,0 +1,101 @@
+package monitor
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sync"
+	"time"
+)
+
+// Store is an append-only file with one JSON Probe per line (JSON Lines).
+//
+// Appending never rewrites earlier lines, so a crash can at most cut off the last line,
+// which LoadProbes skips. OpenStore ends such a line before appending, so the first probe
+// after a restart is not glued to it. The file can be inspected with any text tool, e.g. tail -f or jq.
+type Store struct {
+	mu   sync.Mutex
+	file *os.File
+	enc  *json.Encoder
+}
+
+// OpenStore opens the store at path for appending, creating it if needed.
+func OpenStore(path string) (*Store, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0664)
+	if err != nil {
+		return nil, err
+	}
+	if err := endLastLine(f); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("repairing %s: %w", path, err)
+	}
+	return &Store{file: f, enc: json.NewEncoder(f)}, nil
+}
+
+// endLastLine writes a newline if f does not end with one, i.e. the last line was cut off.
+// The broken line stays in the file (LoadProbes skips it), but the next line starts cleanly.
+func endLastLine(f *os.File) error {
+	info, err := f.Stat()
+	if err != nil || info.Size() == 0 {
+		return err
+	}
+	last := make([]byte, 1)
+	if _, err := f.ReadAt(last, info.Size()-1); err != nil {
+		return err
+	}
+	if last[0] == '\n' {
+		return nil
+	}
+	_, err = f.Write([]byte{'\n'})
+	return err
+}
+
+// Append writes p as a new line at the end of the store. It is safe for concurrent use.
+func (s *Store) Append(p Probe) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// Encode writes the JSON and the newline in one Write call
+	return s.enc.Encode(p)
+}
+
+// Close closes the file.
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
+
+// LoadProbes reads every probe at or after since from the store at path, in file order.
+// A missing file is an empty store. Lines that cannot be parsed (e.g. a line cut off by a crash)
+// are skipped; the number of skipped lines is returned.
+func LoadProbes(path string, since time.Time) (probes []Probe, skipped int, err error) {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, 0, nil
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	// Error messages can make lines longer than the default 64 KiB limit
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		var p Probe
+		if err := json.Unmarshal(scanner.Bytes(), &p); err != nil {
+			skipped++
+			continue
+		}
+		if !p.Time.Before(since) {
+			probes = append(probes, p)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return probes, skipped, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return probes, skipped, nil
+}
@@ -0,0 +1,80 @@
+package monitor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStoreAppendAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "probes.jsonl")
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	s, err := OpenStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range 3 {
+		if err := s.Append(Probe{URL: "https://example.com", Time: start.Add(time.Duration(i) * time.Minute), Up: true}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	s.Close()
+
+	probes, skipped, err := LoadProbes(path, start.Add(time.Minute))
+	if err != nil || skipped != 0 || len(probes) != 2 {
+		t.Fatalf("LoadProbes = %d probes, %d skipped, %v; want 2, 0, nil", len(probes), skipped, err)
+	}
+
+	// A missing file is an empty store
+	if probes, _, err := LoadProbes(filepath.Join(t.TempDir(), "missing"), start); err != nil || len(probes) != 0 {
+		t.Fatalf("LoadProbes(missing) = %v, %v", probes, err)
+	}
+}
+
+// TestStoreCutOffLine simulates a crash in the middle of a line: the probe appended
+// after reopening the store must still be loaded, and only the broken line skipped.
+func TestStoreCutOffLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "probes.jsonl")
+	now := time.Now()
+
+	s, err := OpenStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Append(Probe{URL: "https://a.example", Time: now})
+	s.Close()
+
+	// The crash: half a line without its newline
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString(`{"url":"https://b.example","ti`)
+	f.Close()
+
+	// The restart
+	s, err = OpenStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Append(Probe{URL: "https://c.example", Time: now})
+	s.Close()
+
+	probes, skipped, err := LoadProbes(path, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if skipped != 1 || len(probes) != 2 || probes[0].URL != "https://a.example" || probes[1].URL != "https://c.example" {
+		t.Fatalf("LoadProbes = %+v, %d skipped; want a.example and c.example, 1 skipped", probes, skipped)
+	}
+
+	// Reopening a store that ends cleanly must not add empty lines
+	before, _ := os.Stat(path)
+	s, _ = OpenStore(path)
+	s.Close()
+	if after, _ := os.Stat(path); after.Size() != before.Size() {
+		t.Fatalf("reopening changed the size from %d to %d", before.Size(), after.Size())
+	}
+}
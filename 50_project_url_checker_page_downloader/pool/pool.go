@@ -0,0 +1,52 @@
+// Package pool runs many jobs on a fixed number of goroutines.
+package pool
+
+import (
+	"context"
+	"sync"
+)
+
+// Run calls work(i) for every i in [0, n) on at most workers goroutines,
+// so n = 10,000 URLs never means 10,000 connections at once.
+//
+// Once stop is done, no new index is handed out and Run waits only for the calls already running.
+// It does not interrupt them itself: work should use a context derived from stop (or a stricter one).
+// It returns how many indices were handed out; indices from that number on were never started.
+//
+// Unlike one goroutine per job with a semaphore, the number of goroutines stays at workers,
+// however many jobs there are.
+func Run(stop context.Context, workers, n int, work func(i int)) (started int) {
+	jobs := make(chan int) // unbuffered: an index is only handed out when a worker is free
+
+	// 1. Start the workers; each one takes indices until the channel is closed
+	var wg sync.WaitGroup
+	for range min(workers, n) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				work(i)
+			}
+		}()
+	}
+
+	// 2. Hand out the indices in order until they run out or stop is done
+feed:
+	for started < n {
+		// Check stop first: select picks at random when a worker is free at the same time
+		if stop.Err() != nil {
+			break
+		}
+		select {
+		case jobs <- started:
+			started++
+		case <-stop.Done():
+			break feed
+		}
+	}
+	close(jobs)
+
+	// 3. Wait for the calls in flight
+	wg.Wait()
+	return started
+}
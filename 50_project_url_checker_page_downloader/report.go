@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+)
+
+// printSummary prints one aligned row per URL followed by the number of URLs UP, DOWN and SKIPPED
+func printSummary(w io.Writer, results []result) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "URL\tSTATE\tCODE\tTRIES\tTIME\tDETAILS")
+
+	up, skipped := 0, 0
+	for _, r := range results {
+		state := "DOWN"
+		switch {
+		case r.skipped:
+			state = "SKIPPED"
+			skipped++
+		case r.Up():
+			state = "UP"
+			up++
+		}
+
+		code := "-"
+		if r.statusCode != 0 {
+			code = fmt.Sprint(r.statusCode)
+		}
+
+		details := ""
+		switch {
+		case r.err != nil:
+			details = r.err.Error()
+		case r.unchanged:
+			details = "unchanged, kept " + r.file
+		case r.resumedAt > 0:
+			details = fmt.Sprintf("resumed at %d bytes, saved to %s", r.resumedAt, r.file)
+		case r.file != "":
+			details = "saved to " + r.file
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\t%s\n", r.url, state, code, r.attempts, r.elapsed.Round(time.Millisecond), details)
+	}
+	tw.Flush()
+
+	fmt.Fprintf(w, "\n%d UP, %d DOWN", up, len(results)-up-skipped)
+	if skipped > 0 {
+		fmt.Fprintf(w, ", %d SKIPPED", skipped)
+	}
+	fmt.Fprintln(w)
+}
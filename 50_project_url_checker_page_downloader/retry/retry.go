@@ -0,0 +1,155 @@
+// Package retry repeats HTTP requests that failed for a temporary reason,
+// waiting longer and longer between attempts (exponential backoff with full jitter).
+package retry
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// Policy decides whether and when a failed request is tried again.
+// Its zero value never retries; Default returns a sensible policy.
+// A Policy is safe to share between goroutines.
+type Policy struct {
+	MaxAttempts int           // Total attempts, including the first one (values below 1 mean 1)
+	BaseDelay   time.Duration // Upper bound of the first backoff; it doubles after every attempt
+	MaxDelay    time.Duration // Upper bound of any backoff, and the longest Retry-After that is honoured (0 means no limit)
+
+	// RetryableStatus lists the status codes worth retrying.
+	// Network errors such as timeouts, refused or reset connections are always retryable.
+	RetryableStatus []int
+}
+
+// Default returns a policy with 3 attempts, backoff starting at 500ms and capped at 30s,
+// retrying 429 Too Many Requests, 502 Bad Gateway, 503 Service Unavailable and 504 Gateway Timeout.
+func Default() Policy {
+	return Policy{
+		MaxAttempts: 3,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    30 * time.Second,
+		RetryableStatus: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// Backoff returns how long to wait after the given failed attempt (0 = the first one).
+//
+// "Full jitter" picks a random duration between 0 and min(MaxDelay, BaseDelay·2^attempt),
+// so many clients failing at the same moment do not all retry at the same moment again.
+func (p Policy) Backoff(attempt int) time.Duration {
+	if p.BaseDelay <= 0 {
+		return 0
+	}
+	// BaseDelay·2^attempt, saturating where the shift would overflow
+	ceiling := time.Duration(math.MaxInt64)
+	if shift := max(attempt, 0); shift < 63 && p.BaseDelay <= ceiling>>shift {
+		ceiling = p.BaseDelay << shift
+	}
+	if p.MaxDelay > 0 {
+		ceiling = min(ceiling, p.MaxDelay)
+	}
+	return rand.N(min(ceiling, math.MaxInt64-1) + 1)
+}
+
+// Retryable reports whether a request that ended with resp or err is worth trying again.
+// Cancelled contexts, DNS failures and invalid requests are not retryable.
+func (p Policy) Retryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return retryableError(err)
+	}
+	return slices.Contains(p.RetryableStatus, resp.StatusCode)
+}
+
+// retryableError reports whether err looks temporary
+func retryableError(err error) bool {
+	// The caller gave up: retrying would only fail again
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	// Timeouts of a single attempt, e.g. http.Client.Timeout
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	// The server was restarting or dropped the connection half way
+	return errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF)
+}
+
+// RetryAfter parses the Retry-After header of resp, given either in seconds ("120")
+// or as an HTTP date. ok is false when the header is missing or invalid.
+func RetryAfter(resp *http.Response, now time.Time) (d time.Duration, ok bool) {
+	if resp == nil {
+		return 0, false
+	}
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(t.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// Do sends req with client until it succeeds, fails for good, or runs out of attempts,
+// and returns the last response or error together with the number of attempts made.
+//
+// Between attempts it waits for Backoff, or for the Retry-After the server asked for.
+// A Retry-After longer than MaxDelay is not honoured by waiting: Do returns that response instead.
+// Waiting stops as soon as ctx is done.
+//
+// ⚠️ req is sent several times, so it must not have a body (GET and HEAD requests are fine).
+// The caller must close the body of the returned response, like with client.Do.
+func (p Policy) Do(ctx context.Context, client *http.Client, req *http.Request) (*http.Response, int, error) {
+	maxAttempts := max(p.MaxAttempts, 1)
+
+	for attempt := 0; ; attempt++ {
+		resp, err := client.Do(req.Clone(ctx))
+		if attempt+1 >= maxAttempts || ctx.Err() != nil || !p.Retryable(resp, err) {
+			return resp, attempt + 1, err
+		}
+
+		delay := p.Backoff(attempt)
+		if after, ok := RetryAfter(resp, time.Now()); ok {
+			if p.MaxDelay > 0 && after > p.MaxDelay {
+				return resp, attempt + 1, err
+			}
+			delay = after
+		}
+
+		if resp != nil {
+			// Drain and close the body so the connection can be reused by the next attempt
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, attempt + 1, ctx.Err()
+		}
+	}
+}
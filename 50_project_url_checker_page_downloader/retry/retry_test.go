@@ -0,0 +1,221 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	capped := Policy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	tests := []struct {
+		name    string
+		policy  Policy
+		attempt int
+		ceiling time.Duration
+	}{
+		{"first attempt", capped, 0, 100 * time.Millisecond},
+		{"negative attempt", capped, -1, 100 * time.Millisecond},
+		{"doubled", capped, 2, 400 * time.Millisecond},
+		{"capped", capped, 4, time.Second},
+		{"capped far out", capped, 1000, time.Second},
+		{"no base delay", Policy{MaxDelay: time.Second}, 3, 0},
+		{"no limit", Policy{BaseDelay: time.Millisecond}, 10, 1024 * time.Millisecond},
+		{"last shift", Policy{BaseDelay: 1}, 62, 1 << 62},
+		// BaseDelay·2^attempt overflows: without MaxDelay the ceiling saturates instead of wrapping
+		{"overflow", Policy{BaseDelay: time.Hour}, 40, math.MaxInt64},
+		{"overflow by one", Policy{BaseDelay: 1}, 63, math.MaxInt64},
+		{"overflow capped", Policy{BaseDelay: time.Hour, MaxDelay: time.Minute}, 40, time.Minute},
+	}
+	for _, tt := range tests {
+		var longest time.Duration
+		for range 200 {
+			d := tt.policy.Backoff(tt.attempt)
+			if d < 0 || d > tt.ceiling {
+				t.Fatalf("%s: Backoff(%d) = %v, want between 0 and %v", tt.name, tt.attempt, d, tt.ceiling)
+			}
+			longest = max(longest, d)
+		}
+		// Full jitter spreads over the whole range: 200 draws all in the lower half are as good as impossible
+		if longest < tt.ceiling/2 {
+			t.Errorf("%s: longest of 200 Backoff(%d) = %v, want some above %v", tt.name, tt.attempt, longest, tt.ceiling/2)
+		}
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		header string
+		want   time.Duration
+		ok     bool
+	}{
+		{"120", 2 * time.Minute, true},
+		{"0", 0, true},
+		{now.Add(90 * time.Second).Format(http.TimeFormat), 90 * time.Second, true},
+		{now.Add(45 * time.Second).Format(time.RFC850), 45 * time.Second, true},
+		{now.Add(-time.Hour).Format(http.TimeFormat), 0, true}, // Already past: retry right away
+		{"-5", 0, false},
+		{"1.5", 0, false},
+		{"soon", 0, false},
+		{"", 0, false},
+	}
+	for _, tt := range tests {
+		resp := &http.Response{Header: http.Header{}}
+		if tt.header != "" {
+			resp.Header.Set("Retry-After", tt.header)
+		}
+		if got, ok := RetryAfter(resp, now); got != tt.want || ok != tt.ok {
+			t.Errorf("RetryAfter(%q) = %v, %v; want %v, %v", tt.header, got, ok, tt.want, tt.ok)
+		}
+	}
+	if _, ok := RetryAfter(nil, now); ok {
+		t.Error("RetryAfter(nil) reported ok")
+	}
+}
+
+// timeoutError is a net.Error that timed out
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestRetryable(t *testing.T) {
+	p := Default()
+	for status, want := range map[int]bool{
+		http.StatusTooManyRequests:     true,
+		http.StatusBadGateway:          true,
+		http.StatusServiceUnavailable:  true,
+		http.StatusGatewayTimeout:      true,
+		http.StatusOK:                  false,
+		http.StatusNotModified:         false,
+		http.StatusNotFound:            false,
+		http.StatusInternalServerError: false,
+		http.StatusNotImplemented:      false,
+	} {
+		if got := p.Retryable(&http.Response{StatusCode: status}, nil); got != want {
+			t.Errorf("Retryable(%d) = %v, want %v", status, got, want)
+		}
+		if (Policy{}).Retryable(&http.Response{StatusCode: status}, nil) {
+			t.Errorf("the zero Policy retries %d", status)
+		}
+	}
+
+	errs := []struct {
+		err  error
+		want bool
+	}{
+		{&net.OpError{Op: "dial", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}, true},
+		{fmt.Errorf("read: %w", syscall.ECONNRESET), true},
+		{io.ErrUnexpectedEOF, true},
+		{&net.OpError{Op: "read", Err: timeoutError{}}, true},
+		{context.Canceled, false},
+		{fmt.Errorf("get: %w", context.Canceled), false},
+		{&net.DNSError{Err: "no such host", Name: "nowhere.invalid", IsNotFound: true}, false},
+		{errors.New("unsupported protocol scheme"), false},
+	}
+	for _, tt := range errs {
+		if got := p.Retryable(nil, tt.err); got != tt.want {
+			t.Errorf("Retryable(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+// failingServer answers the first failures requests with status and the headers h, then 200
+func failingServer(t *testing.T, failures int32, status int, h http.Header) (*httptest.Server, *atomic.Int32) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) <= failures {
+			for k, v := range h {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(status)
+			io.WriteString(w, "try again")
+			return
+		}
+		io.WriteString(w, "ok")
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &requests
+}
+
+func TestDo(t *testing.T) {
+	fast := Default()
+	fast.BaseDelay = 0
+
+	tests := []struct {
+		name     string
+		policy   Policy
+		failures int32
+		status   int
+		header   http.Header
+		want     int // Status of the returned response
+		attempts int
+	}{
+		{"succeeds after failures", fast, 2, http.StatusServiceUnavailable, nil, http.StatusOK, 3},
+		{"runs out of attempts", fast, 5, http.StatusBadGateway, nil, http.StatusBadGateway, 3},
+		{"not retryable", fast, 5, http.StatusNotFound, nil, http.StatusNotFound, 1},
+		{"zero policy", Policy{}, 5, http.StatusServiceUnavailable, nil, http.StatusServiceUnavailable, 1},
+		{"short Retry-After", fast, 1, http.StatusTooManyRequests, http.Header{"Retry-After": {"0"}}, http.StatusOK, 2},
+		// Waiting a minute is more than MaxDelay allows: the 429 is returned instead
+		{"long Retry-After", fast, 1, http.StatusTooManyRequests, http.Header{"Retry-After": {"60"}}, http.StatusTooManyRequests, 1},
+	}
+	for _, tt := range tests {
+		srv, requests := failingServer(t, tt.failures, tt.status, tt.header)
+		req, err := http.NewRequest(http.MethodGet, srv.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, attempts, err := tt.policy.Do(context.Background(), srv.Client(), req)
+		if err != nil {
+			t.Fatalf("%s: Do error = %v", tt.name, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tt.want || attempts != tt.attempts || int(requests.Load()) != tt.attempts {
+			t.Errorf("%s: Do = %d after %d attempts (%d requests); want %d after %d",
+				tt.name, resp.StatusCode, attempts, requests.Load(), tt.want, tt.attempts)
+		}
+	}
+}
+
+func TestDoCancel(t *testing.T) {
+	// The server asks for a long wait that MaxDelay allows; cancelling ends it
+	srv, requests := failingServer(t, 5, http.StatusServiceUnavailable, http.Header{"Retry-After": {"30"}})
+	p := Default()
+	p.MaxDelay = time.Minute
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	req, err := http.NewRequest(http.MethodGet, srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	resp, attempts, err := p.Do(ctx, srv.Client(), req)
+	if resp != nil || attempts != 1 || !errors.Is(err, context.Canceled) {
+		t.Fatalf("Do = %v, %d, %v; want nil, 1, context.Canceled", resp, attempts, err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("Do returned %v after the cancel", elapsed)
+	}
+	if requests.Load() != 1 {
+		t.Fatalf("%d requests, want 1", requests.Load())
+	}
+
+	// Already cancelled: the only attempt fails and nothing is retried
+	resp, attempts, err = p.Do(ctx, srv.Client(), req)
+	if resp != nil || attempts != 1 || !errors.Is(err, context.Canceled) {
+		t.Fatalf("Do with a cancelled context = %v, %d, %v", resp, attempts, err)
+	}
+}
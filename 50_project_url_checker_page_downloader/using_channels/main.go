@@ -1,77 +1,194 @@
+// using_channels is a long-running uptime monitor.
+//
+// Every URL is checked by its own goroutine at a fixed interval, and the results come back
+// over a channel (see monitor.Monitor.Run). Every probe is appended to a JSON Lines file;
+// uptime and p50/p95/p99 latency are printed per URL over sliding windows,
+// and an alert is sent whenever a URL goes from UP to DOWN or back.
+//
+// Run:
+//
+//	go run ./50_project_url_checker_page_downloader/using_channels
+//	go run ./50_project_url_checker_page_downloader/using_channels -interval 10s -report 1m https://go.dev https://example.com
+//	go run ./50_project_url_checker_page_downloader/using_channels -webhook https://hooks.slack.com/services/... -alerts alerts.jsonl
+//	go run ./50_project_url_checker_page_downloader/using_channels -http :8080    # then open http://localhost:8080
+//
+// With -http, a live status page is served at that address (see newStatusServer).
+// Restarting with the same -store continues the history where it stopped.
+// Stop it with Ctrl-C.
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
+	"io"
+	"log"
 	"net/http"
-	"runtime"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"text/tabwriter"
 	"time"
+
+	"github.com/gofiber/fiber/v3"
+
+	"master_go_programming/50_project_url_checker_page_downloader/monitor"
+	"master_go_programming/50_project_url_checker_page_downloader/retry"
 )
 
-// checkAndSaveBody makes an HTTP GET request to the given URL.
-// It sends back the result (status message) to the channel `c`.
-func checkAndSaveBody(url string, c chan string) {
-	resp, err := http.Get(url)
+func main() {
+	interval := flag.Duration("interval", 30*time.Second, "time between two checks of the same URL")
+	timeout := flag.Duration("timeout", 10*time.Second, "time limit for each attempt")
+	storePath := flag.String("store", "probes.jsonl", "append-only file with every probe")
+	windowList := flag.String("windows", "1h,24h", "comma-separated sliding windows for uptime and latency")
+	report := flag.Duration("report", 5*time.Minute, "how often to print the stats table (0 = only at exit)")
+	webhook := flag.String("webhook", "", "URL to POST alerts to as JSON")
+	alertFile := flag.String("alerts", "", "file to append alerts to as JSON lines")
+	quiet := flag.Bool("q", false, "do not print every probe")
+	addr := flag.String("http", "", `address for the status page, e.g. ":8080" ("" = no server)`)
+	flag.Parse()
 
+	// List of websites to check
+	urls := flag.Args()
+	if len(urls) == 0 {
+		urls = []string{
+			"https://www.golang.org",
+			"https://www.google.com",
+			"https://www.medium.com",
+		}
+	}
+
+	windows, err := parseWindows(*windowList)
 	if err != nil {
-		// If the request fails, send DOWN message into the channel
-		s := fmt.Sprintf("%s is DOWN!\n", url)
-		s += fmt.Sprintf("Error: %v\n", err)
-		c <- s
-	} else {
-		// If the request succeeds, send UP message with status code
-		s := fmt.Sprintf("%s -> Status code: %d \n", url, resp.StatusCode)
-		s += fmt.Sprintf("%s is UP\n", url)
-		c <- s
+		log.Fatal(err)
+	}
+	if *interval <= 0 {
+		log.Fatal("-interval must be positive")
 	}
-}
 
-func main() {
-	// List of websites to check
-	urls := []string{
-		"https://www.golang.org",
-		"https://www.google.com",
-		"https://www.medium.com",
+	// Alerts always go to stdout, and to the webhook and the file when they are given
+	notifiers := monitor.MultiNotifier{monitor.WriterNotifier{W: os.Stdout}}
+	if *webhook != "" {
+		notifiers = append(notifiers, monitor.WebhookNotifier{URL: *webhook})
+	}
+	if *alertFile != "" {
+		notifiers = append(notifiers, &monitor.FileNotifier{Path: *alertFile})
 	}
 
-	// Create a channel to communicate between goroutines
-	c := make(chan string)
+	// Continue from the probes stored by earlier runs
+	history, skipped, err := monitor.LoadProbes(*storePath, time.Now().Add(-maxDuration(windows)))
+	if err != nil {
+		log.Fatal(err)
+	}
+	if skipped > 0 {
+		log.Printf("skipped %d unreadable lines in %s", skipped, *storePath)
+	}
+	store, err := monitor.OpenStore(*storePath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer store.Close()
 
-	// Launch a goroutine for each URL check
-	for _, url := range urls {
-		go checkAndSaveBody(url, c)
+	opts := monitor.Options{
+		Interval: *interval,
+		Windows:  windows,
+		Client:   &http.Client{Timeout: *timeout},
+		Policy:   retry.Default(),
+		Store:    store,
+		Notifier: notifiers,
+	}
+	if !*quiet {
+		opts.OnProbe = printProbe
 	}
+	m := monitor.New(urls, opts)
+	m.Load(history)
 
-	// Print how many goroutines are currently running
-	fmt.Println("No. of Goroutines:", runtime.NumGoroutine())
+	// Ctrl-C cancels ctx, which stops every prober
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	/*
-		OPTION 1: Finite loop
-		You can use this block to wait for responses equal to the number of URLs.
-		This prevents infinite looping.
-		Example:
-			for i := 0; i < len(urls); i++ {
-				fmt.Println(<-c)
+	if *report > 0 {
+		go func() {
+			ticker := time.NewTicker(*report)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					printStats(os.Stdout, m.Stats(time.Now()))
+				case <-ctx.Done():
+					return
+				}
 			}
-	*/
+		}()
+	}
 
-	/*
-		OPTION 2: Continuous monitoring loop
-		This block will keep checking URLs indefinitely at fixed intervals.
-		Example:
-			for {
-				go checkAndSaveBody(<-c, c)
-				fmt.Println(strings.Repeat("#", 30))
-				time.Sleep(time.Second * 10)
+	var serverDone chan struct{}
+	if *addr != "" {
+		app := newStatusServer(ctx, m)
+		serverDone = make(chan struct{})
+		go func() {
+			defer close(serverDone)
+			// GracefulContext shuts the server down when ctx is cancelled
+			err := app.Listen(*addr, fiber.ListenConfig{GracefulContext: ctx, DisableStartupMessage: true})
+			if err != nil {
+				log.Printf("status page: %v", err)
 			}
-	*/
+		}()
+		fmt.Printf("Status page on %s\n", *addr)
+	}
+
+	fmt.Printf("Monitoring %d URLs every %s, storing probes in %s (Ctrl-C to stop)\n", len(urls), *interval, *storePath)
+	m.Run(ctx)
+	if serverDone != nil {
+		<-serverDone
+	}
+	printStats(os.Stdout, m.Stats(time.Now()))
+}
+
+// printProbe prints one line per probe, like the original UP/DOWN messages
+func printProbe(p monitor.Probe) {
+	if p.Up {
+		fmt.Printf("%s -> Status code: %d in %s, %s is UP\n", p.URL, p.StatusCode, p.Latency.Round(time.Millisecond), p.URL)
+		return
+	}
+	detail := p.Error
+	if detail == "" {
+		detail = fmt.Sprintf("status code %d", p.StatusCode)
+	}
+	fmt.Printf("%s is DOWN! (%s)\n", p.URL, detail)
+}
+
+// printStats prints one row per URL and window
+func printStats(w io.Writer, stats []monitor.Stats) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "URL\tWINDOW\tPROBES\tUPTIME\tP50\tP95\tP99")
+	for _, s := range stats {
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%.2f%%\t%s\t%s\t%s\n", s.URL, s.Window, s.Probes, s.Uptime,
+			s.P50.Round(time.Millisecond), s.P95.Round(time.Millisecond), s.P99.Round(time.Millisecond))
+	}
+	tw.Flush()
+	fmt.Fprintln(w, strings.Repeat("#", 30))
+}
+
+// parseWindows parses a comma-separated list of durations such as "1h,24h"
+func parseWindows(list string) ([]time.Duration, error) {
+	var windows []time.Duration
+	for _, s := range strings.Split(list, ",") {
+		d, err := time.ParseDuration(strings.TrimSpace(s))
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid window %q", s)
+		}
+		windows = append(windows, d)
+	}
+	return windows, nil
+}
 
-	// Current implementation: Infinite loop using `range c`
-	// Reads messages from the channel `c`
-	for url := range c {
-		// Launch another goroutine to re-check the URL every 2 seconds
-		go func(u string) {
-			time.Sleep(time.Second * 2)  // wait before re-checking
-			checkAndSaveBody(u, c)       // re-check and send result again
-		}(url)
+// maxDuration returns the largest of durations
+func maxDuration(durations []time.Duration) time.Duration {
+	var m time.Duration
+	for _, d := range durations {
+		m = max(m, d)
 	}
+	return m
 }
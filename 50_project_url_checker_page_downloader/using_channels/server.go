@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+
+	"master_go_programming/50_project_url_checker_page_downloader/monitor"
+)
+
+// statusPage is the whole HTML page; its script renders /api/events in the browser
+//
+//go:embed status.html
+var statusPage string
+
+// heartbeatInterval is how often an idle event stream sends a comment,
+// which keeps proxies from closing it and notices clients that went away
+const heartbeatInterval = 15 * time.Second
+
+// newStatusServer returns a Fiber app serving the status of m:
+//
+//	GET /             live HTML status page
+//	GET /api/status   current status of every URL as JSON
+//	GET /api/events   Server-Sent Events: one "status" event per URL, then one after every probe
+//
+// The event streams end when ctx is cancelled, so the server can shut down gracefully.
+func newStatusServer(ctx context.Context, m *monitor.Monitor) *fiber.App {
+	app := fiber.New()
+
+	app.Get("/", func(c fiber.Ctx) error {
+		c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+		return c.SendString(statusPage)
+	})
+
+	app.Get("/api/status", func(c fiber.Ctx) error {
+		return c.JSON(m.Status(time.Now()))
+	})
+
+	app.Get("/api/events", func(c fiber.Ctx) error {
+		c.Set(fiber.HeaderContentType, "text/event-stream")
+		c.Set(fiber.HeaderCacheControl, "no-cache")
+		c.Set(fiber.HeaderConnection, "keep-alive")
+		// Ask nginx and similar proxies not to buffer the stream
+		c.Set("X-Accel-Buffering", "no")
+
+		return c.SendStreamWriter(func(w *bufio.Writer) {
+			streamEvents(ctx, w, m)
+		})
+	})
+
+	return app
+}
+
+// streamEvents writes the current status of every URL, then every update,
+// until the client disconnects or ctx is cancelled.
+func streamEvents(ctx context.Context, w *bufio.Writer, m *monitor.Monitor) {
+	// Subscribe before taking the snapshot so no update falls in between
+	updates, unsubscribe := m.Subscribe()
+	defer unsubscribe()
+
+	for _, s := range m.Status(time.Now()) {
+		writeEvent(w, "status", s)
+	}
+	if w.Flush() != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case s := <-updates:
+			writeEvent(w, "status", s)
+		case <-heartbeat.C:
+			// Lines starting with ":" are comments, ignored by EventSource
+			fmt.Fprint(w, ": ping\n\n")
+		case <-ctx.Done():
+			return
+		}
+		// Flush fails once the client has disconnected
+		if w.Flush() != nil {
+			return
+		}
+	}
+}
+
+// writeEvent writes one Server-Sent Event with a JSON payload.
+// JSON never contains a raw newline, so the payload always fits on one "data:" line.
+func writeEvent(w *bufio.Writer, event string, v any) {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, payload)
+}
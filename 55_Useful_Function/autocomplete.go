@@ -0,0 +1,70 @@
+package main
+
+import (
+	"log"
+	"strings"
+
+	"master_go_programming/56_data_structure_algorithm/data"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// SampleAutocomplete serves name suggestions for customers and products.
+//
+// Try it with:
+//
+//	curl "localhost:3000/suggest/customers?q=jo&limit=3"
+//	curl "localhost:3000/suggest/products?q=lap"
+func SampleAutocomplete() {
+	// Keys are lower-cased so the search is case-insensitive.
+	// The weight decides which suggestions come first (e.g. number of orders or sales).
+	customers := &data.Trie[Customer]{}
+	for _, c := range []struct {
+		customer Customer
+		orders   float64
+	}{
+		{Customer{ID: 1, Name: "John Smith", Email: "john@example.com"}, 12},
+		{Customer{ID: 2, Name: "Joanna Reyes", Email: "joanna@example.com"}, 30},
+		{Customer{ID: 3, Name: "Jose Santos"}, 5},
+		{Customer{ID: 4, Name: "Maria Cruz", Email: "maria@example.com"}, 8},
+	} {
+		customers.Insert(strings.ToLower(c.customer.Name), c.customer, c.orders)
+	}
+
+	products := &data.Trie[Product]{}
+	for _, p := range []struct {
+		product Product
+		sales   float64
+	}{
+		{Product{ID: 1, Name: "Laptop"}, 120},
+		{Product{ID: 2, Name: "Laptop Stand"}, 45},
+		{Product{ID: 3, Name: "Lamp"}, 60},
+		{Product{ID: 4, Name: "Keyboard"}, 80},
+	} {
+		products.Insert(strings.ToLower(p.product.Name), p.product, p.sales)
+	}
+
+	app := fiber.New()
+
+	app.Get("/suggest/customers", func(c fiber.Ctx) error {
+		return c.JSON(suggest(c, customers))
+	})
+
+	app.Get("/suggest/products", func(c fiber.Ctx) error {
+		return c.JSON(suggest(c, products))
+	})
+
+	log.Fatal(app.Listen(":3000"))
+}
+
+// suggest reads ?q= and ?limit= (default 5) and returns the best completions, never null.
+func suggest[V any](c fiber.Ctx, names *data.Trie[V]) []data.Completion[V] {
+	prefix := strings.ToLower(c.Query("q"))
+	limit := fiber.Query(c, "limit", 5)
+
+	results := names.TopK(prefix, limit)
+	if results == nil {
+		results = []data.Completion[V]{}
+	}
+	return results
+}
@@ -35,6 +35,7 @@ func main() {
 	// SampleJSONtoIndent()
 	// SampleJSONarrays()
 	// SampleFiber()
+	// SampleAutocomplete()
 	// CheckValidJSON()
 	// ListOfMethods()
 
@@ -1,33 +1,111 @@
 package data
 
-// BinarySearch performs a binary search on a sorted slice of integers.
-// 🔹 needle: the value to search for
-// 🔹 haystack: a sorted slice of integers
-// Returns true if needle exists in the slice, false otherwise.
+import "cmp"
+
+// BinarySearch reports whether target is present in a sorted slice.
+//
+// 🔹 haystack: a slice sorted in ascending order
+// 🔹 target: the value to search for
+//
+// The arguments come in the same order as in every other search in this package
+// (and in slices.BinarySearch). Use LowerBound or EqualRange when you also need the position.
 //
 // ⚠️ Important: The slice must be sorted in ascending order before calling this function.
-func BinarySearch(needle int, haystack []int) bool {
-	low := 0
-	high := len(haystack) - 1
+//
+// Time Complexity: O(log n)
+func BinarySearch[T cmp.Ordered](haystack []T, target T) bool {
+	low := LowerBound(haystack, target)
+
+	// Check if low points to the target
+	return low < len(haystack) && haystack[low] == target
+}
+
+// Search returns the smallest index i for which pred(items[i]) is true,
+// or len(items) if there is none.
+//
+// 🔹 items: a slice in which pred is false for some prefix and true for the rest
+// 🔹 pred: the condition to search for
+//
+// Every other search in this file is built on Search. For example, the first
+// order with a total of at least 100 in orders sorted by total:
+//
+//	i := data.Search(orders, func(o Order) bool { return o.Total >= 100 })
+//
+// Time Complexity: O(log n)
+// Space Complexity: O(1)
+func Search[T any](items []T, pred func(T) bool) int {
+	low, high := 0, len(items)
 
-	// Binary search loop
-	for low <= high {
-		// Calculate the middle index
-		median := (low + high) / 2
+	// Invariant: pred is false before low and true from high onwards
+	for low < high {
+		// Calculate the middle index without overflowing
+		median := int(uint(low+high) >> 1)
 
-		if haystack[median] < needle {
-			// If middle value is less than needle, search in the right half
+		if !pred(items[median]) {
+			// The answer is in the right half
 			low = median + 1
 		} else {
-			// Otherwise, search in the left half
-			high = median - 1
+			// median may be the answer, keep it in range
+			high = median
 		}
 	}
 
-	// Check if low points to the needle
-	if low == len(haystack) || haystack[low] != needle {
-		return false
-	}
+	return low
+}
+
+// LowerBound returns the index of the first element that is not less than key.
+// That is the position of the first occurrence of key if present,
+// otherwise the position where key would be inserted to keep items sorted.
+//
+// 🔹 items: a slice sorted in ascending order
+// 🔹 key: the value to search for
+//
+// Time Complexity: O(log n)
+func LowerBound[T cmp.Ordered](items []T, key T) int {
+	return LowerBoundFunc(items, key, cmp.Compare[T])
+}
+
+// LowerBoundFunc is LowerBound for items sorted by compare.
+// The key may have a different type than the items, e.g. an ID looked up in a slice of customers:
+//
+//	i := data.LowerBoundFunc(customers, 42, func(c Customer, id int) int { return cmp.Compare(c.ID, id) })
+func LowerBoundFunc[T, K any](items []T, key K, compare func(item T, key K) int) int {
+	return Search(items, func(item T) bool { return compare(item, key) >= 0 })
+}
+
+// UpperBound returns the index of the first element that is greater than key,
+// i.e. the position just after the last occurrence of key.
+//
+// 🔹 items: a slice sorted in ascending order
+// 🔹 key: the value to search for
+//
+// Time Complexity: O(log n)
+func UpperBound[T cmp.Ordered](items []T, key T) int {
+	return UpperBoundFunc(items, key, cmp.Compare[T])
+}
+
+// UpperBoundFunc is UpperBound for items sorted by compare.
+func UpperBoundFunc[T, K any](items []T, key K, compare func(item T, key K) int) int {
+	return Search(items, func(item T) bool { return compare(item, key) > 0 })
+}
+
+// EqualRange returns the half-open range [low, high) of elements equal to key.
+// The range is empty (low == high) when key is not present, and high-low counts the duplicates.
+//
+// 🔹 items: a slice sorted in ascending order
+// 🔹 key: the value to search for
+//
+// Time Complexity: O(log n)
+func EqualRange[T cmp.Ordered](items []T, key T) (low, high int) {
+	return EqualRangeFunc(items, key, cmp.Compare[T])
+}
+
+// EqualRangeFunc is EqualRange for items sorted by compare.
+func EqualRangeFunc[T, K any](items []T, key K, compare func(item T, key K) int) (low, high int) {
+	low = LowerBoundFunc(items, key, compare)
+
+	// The upper bound can only be at or after the lower bound
+	high = low + UpperBoundFunc(items[low:], key, compare)
 
-	return true
+	return low, high
 }
@@ -0,0 +1,151 @@
+package data
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+)
+
+// sortedFromBytes turns fuzz input into a sorted slice with many duplicates
+func sortedFromBytes(b []byte) []int {
+	items := make([]int, len(b))
+	for i, v := range b {
+		items[i] = int(v % 32)
+	}
+	slices.Sort(items)
+	return items
+}
+
+// addSearchSeeds gives the search fuzzers the edge cases to start from:
+// empty input, a single element, runs of duplicates and targets outside the range
+func addSearchSeeds(f *testing.F) {
+	f.Add([]byte{}, 0)
+	f.Add([]byte{5}, 5)
+	f.Add([]byte{5}, 4)
+	f.Add([]byte{5}, 6)
+	f.Add([]byte{1, 1, 1, 1, 1}, 1)
+	f.Add([]byte{1, 2, 2, 2, 3}, 2)
+	f.Add([]byte{0, 10, 20, 30}, 15)
+	f.Add([]byte{0, 10, 20, 30}, -1)
+	f.Add([]byte{0, 10, 20, 30}, 100)
+}
+
+// linearLowerBound is LowerBound by a linear scan
+func linearLowerBound(items []int, key int) int {
+	for i, v := range items {
+		if v >= key {
+			return i
+		}
+	}
+	return len(items)
+}
+
+// linearUpperBound is UpperBound by a linear scan
+func linearUpperBound(items []int, key int) int {
+	for i, v := range items {
+		if v > key {
+			return i
+		}
+	}
+	return len(items)
+}
+
+func FuzzBinarySearch(f *testing.F) {
+	addSearchSeeds(f)
+	f.Fuzz(func(t *testing.T, b []byte, target int) {
+		items := sortedFromBytes(b)
+		if got, want := BinarySearch(items, target), LinearSearch(items, target); got != want {
+			t.Fatalf("BinarySearch(%v, %d) = %v, LinearSearch says %v", items, target, got, want)
+		}
+	})
+}
+
+func FuzzLowerBound(f *testing.F) {
+	addSearchSeeds(f)
+	f.Fuzz(func(t *testing.T, b []byte, key int) {
+		items := sortedFromBytes(b)
+		if got, want := LowerBound(items, key), linearLowerBound(items, key); got != want {
+			t.Fatalf("LowerBound(%v, %d) = %d, want %d", items, key, got, want)
+		}
+	})
+}
+
+func FuzzUpperBound(f *testing.F) {
+	addSearchSeeds(f)
+	f.Fuzz(func(t *testing.T, b []byte, key int) {
+		items := sortedFromBytes(b)
+		if got, want := UpperBound(items, key), linearUpperBound(items, key); got != want {
+			t.Fatalf("UpperBound(%v, %d) = %d, want %d", items, key, got, want)
+		}
+	})
+}
+
+func FuzzEqualRange(f *testing.F) {
+	addSearchSeeds(f)
+	f.Fuzz(func(t *testing.T, b []byte, key int) {
+		items := sortedFromBytes(b)
+		low, high := EqualRange(items, key)
+		if low != linearLowerBound(items, key) || high != linearUpperBound(items, key) {
+			t.Fatalf("EqualRange(%v, %d) = [%d, %d), want [%d, %d)", items, key, low, high,
+				linearLowerBound(items, key), linearUpperBound(items, key))
+		}
+		// The range holds exactly the copies of key
+		if (high > low) != LinearSearch(items, key) {
+			t.Fatalf("EqualRange(%v, %d) = [%d, %d), but LinearSearch says %v", items, key, low, high, !(high > low))
+		}
+		for _, v := range items[low:high] {
+			if v != key {
+				t.Fatalf("EqualRange(%v, %d) = [%d, %d) contains %d", items, key, low, high, v)
+			}
+		}
+	})
+}
+
+func FuzzSearch(f *testing.F) {
+	addSearchSeeds(f)
+	f.Fuzz(func(t *testing.T, b []byte, key int) {
+		items := sortedFromBytes(b)
+		// The first element whose double is at least key: a predicate that is not a plain comparison
+		pred := func(v int) bool { return 2*v >= key }
+		want := slices.IndexFunc(items, pred)
+		if want < 0 {
+			want = len(items)
+		}
+		if got := Search(items, pred); got != want {
+			t.Fatalf("Search(%v, 2v >= %d) = %d, want %d", items, key, got, want)
+		}
+	})
+}
+
+// TestSearchFuncs checks the Func variants with a key of another type than the items
+func TestSearchFuncs(t *testing.T) {
+	type customer struct {
+		id   int
+		name string
+	}
+	customers := []customer{{1, "a"}, {3, "b"}, {3, "c"}, {3, "d"}, {8, "e"}}
+	byID := func(c customer, id int) int { return cmp.Compare(c.id, id) }
+
+	tests := []struct {
+		id, low, high int
+	}{
+		{0, 0, 0},
+		{1, 0, 1},
+		{2, 1, 1},
+		{3, 1, 4},
+		{8, 4, 5},
+		{9, 5, 5},
+	}
+	for _, tt := range tests {
+		low, high := EqualRangeFunc(customers, tt.id, byID)
+		if low != tt.low || high != tt.high {
+			t.Errorf("EqualRangeFunc(id %d) = [%d, %d), want [%d, %d)", tt.id, low, high, tt.low, tt.high)
+		}
+		if got := LowerBoundFunc(customers, tt.id, byID); got != tt.low {
+			t.Errorf("LowerBoundFunc(id %d) = %d, want %d", tt.id, got, tt.low)
+		}
+		if got := UpperBoundFunc(customers, tt.id, byID); got != tt.high {
+			t.Errorf("UpperBoundFunc(id %d) = %d, want %d", tt.id, got, tt.high)
+		}
+	}
+}
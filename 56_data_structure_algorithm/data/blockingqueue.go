@@ -0,0 +1,91 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrQueueClosed is returned by BlockingQueue.Put after Close,
+// and by BlockingQueue.Take once the queue is closed and empty.
+var ErrQueueClosed = errors.New("data: queue closed")
+
+// BlockingQueue is a bounded FIFO queue for producer/consumer pipelines.
+//
+// Put waits while the queue is full and Take waits while it is empty,
+// which naturally slows fast producers down to the speed of the consumers (back-pressure).
+// Both give up when their context is cancelled or its deadline passes.
+//
+// Internally it is a buffered channel, exactly like in 36_channel_buffed,
+// plus a done channel that lets Close wake up every waiting goroutine.
+type BlockingQueue[T any] struct {
+	items     chan T
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewBlockingQueue creates a queue that holds at most capacity values (at least 1).
+func NewBlockingQueue[T any](capacity int) *BlockingQueue[T] {
+	return &BlockingQueue[T]{
+		items: make(chan T, max(capacity, 1)),
+		done:  make(chan struct{}),
+	}
+}
+
+// Put adds value at the back of the queue, waiting while the queue is full.
+// It returns ctx.Err() if the context ends first, or ErrQueueClosed if the queue is closed.
+func (q *BlockingQueue[T]) Put(ctx context.Context, value T) error {
+	// Fail fast instead of letting select pick the send at random after Close
+	select {
+	case <-q.done:
+		return ErrQueueClosed
+	default:
+	}
+
+	select {
+	case q.items <- value:
+		return nil
+	case <-q.done:
+		return ErrQueueClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Take removes and returns the value at the front of the queue, waiting while the queue is empty.
+// It returns ctx.Err() if the context ends first. After Close, the remaining values
+// can still be taken; then Take returns ErrQueueClosed.
+func (q *BlockingQueue[T]) Take(ctx context.Context) (T, error) {
+	select {
+	case v := <-q.items:
+		return v, nil
+	case <-q.done:
+		// Closed: drain what is left without waiting
+		select {
+		case v := <-q.items:
+			return v, nil
+		default:
+			var zero T
+			return zero, ErrQueueClosed
+		}
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// Len returns the number of values waiting in the queue.
+func (q *BlockingQueue[T]) Len() int {
+	return len(q.items)
+}
+
+// Cap returns the maximum number of values the queue can hold.
+func (q *BlockingQueue[T]) Cap() int {
+	return cap(q.items)
+}
+
+// Close stops the queue from accepting values and wakes up every waiting Put and Take.
+// Calling it more than once is safe.
+func (q *BlockingQueue[T]) Close() {
+	q.closeOnce.Do(func() { close(q.done) })
+}
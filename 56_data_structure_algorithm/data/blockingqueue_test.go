@@ -0,0 +1,212 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBlockingQueuePutTake(t *testing.T) {
+	ctx := context.Background()
+	q := NewBlockingQueue[int](2)
+	if q.Cap() != 2 {
+		t.Fatalf("Cap = %d, want 2", q.Cap())
+	}
+	for i := range 2 {
+		if err := q.Put(ctx, i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if q.Len() != 2 {
+		t.Fatalf("Len = %d, want 2", q.Len())
+	}
+
+	// Full: Put waits until its deadline
+	short, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if err := q.Put(short, 2); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Put on a full queue = %v, want DeadlineExceeded", err)
+	}
+
+	for want := range 2 {
+		if v, err := q.Take(ctx); err != nil || v != want {
+			t.Fatalf("Take = %d, %v, want %d", v, err, want)
+		}
+	}
+
+	// Empty: Take waits until its context is cancelled
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := q.Take(cancelled); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Take on an empty queue = %v, want Canceled", err)
+	}
+}
+
+func TestBlockingQueueClose(t *testing.T) {
+	ctx := context.Background()
+	q := NewBlockingQueue[int](1)
+	q.Put(ctx, 1)
+
+	// A Put waiting on the full queue and a Take waiting on another empty queue
+	// are both woken by Close
+	empty := NewBlockingQueue[int](1)
+	errs := make(chan error, 2)
+	go func() { errs <- q.Put(ctx, 2) }()
+	go func() { _, err := empty.Take(ctx); errs <- err }()
+	time.Sleep(10 * time.Millisecond)
+	q.Close()
+	empty.Close()
+	empty.Close() // safe to call twice
+	for range 2 {
+		select {
+		case err := <-errs:
+			if !errors.Is(err, ErrQueueClosed) {
+				t.Fatalf("waiting call returned %v, want ErrQueueClosed", err)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Close did not wake a waiting call")
+		}
+	}
+
+	if err := q.Put(ctx, 3); !errors.Is(err, ErrQueueClosed) {
+		t.Fatalf("Put after Close = %v, want ErrQueueClosed", err)
+	}
+	// The value put before Close can still be taken, then the queue reports it is closed
+	if v, err := q.Take(ctx); err != nil || v != 1 {
+		t.Fatalf("Take after Close = %d, %v, want 1, nil", v, err)
+	}
+	if _, err := q.Take(ctx); !errors.Is(err, ErrQueueClosed) {
+		t.Fatalf("Take on a closed, empty queue = %v, want ErrQueueClosed", err)
+	}
+}
+
+// TestBlockingQueueStress passes values from many producers to many consumers through
+// a small queue (use -race). Every value must arrive exactly once.
+func TestBlockingQueueStress(t *testing.T) {
+	const producers, consumers, perProducer = 8, 8, 5_000
+	ctx := context.Background()
+	q := NewBlockingQueue[int](4)
+
+	var producing sync.WaitGroup
+	for p := range producers {
+		producing.Go(func() {
+			for seq := range perProducer {
+				if err := q.Put(ctx, p*perProducer+seq); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		})
+	}
+	go func() {
+		producing.Wait()
+		q.Close()
+	}()
+
+	results := make(chan []int, consumers)
+	for range consumers {
+		go func() {
+			var got []int
+			for {
+				v, err := q.Take(ctx)
+				if errors.Is(err, ErrQueueClosed) {
+					results <- got
+					return
+				}
+				got = append(got, v)
+			}
+		}()
+	}
+
+	count := make([]int, producers*perProducer)
+	for range consumers {
+		for _, v := range <-results {
+			count[v]++
+		}
+	}
+	for v, n := range count {
+		if n != 1 {
+			t.Fatalf("value %d taken %d times", v, n)
+		}
+	}
+}
+
+// condQueue is the single-mutex baseline for BlockingQueue:
+// a ring buffer with a sync.Cond for "not full" and one for "not empty"
+type condQueue struct {
+	mu                sync.Mutex
+	notFull, notEmpty *sync.Cond
+	buf               []int
+	head, count       int
+}
+
+func newCondQueue(capacity int) *condQueue {
+	q := &condQueue{buf: make([]int, capacity)}
+	q.notFull = sync.NewCond(&q.mu)
+	q.notEmpty = sync.NewCond(&q.mu)
+	return q
+}
+
+func (q *condQueue) put(v int) {
+	q.mu.Lock()
+	for q.count == len(q.buf) {
+		q.notFull.Wait()
+	}
+	q.buf[(q.head+q.count)%len(q.buf)] = v
+	q.count++
+	q.mu.Unlock()
+	q.notEmpty.Signal()
+}
+
+func (q *condQueue) take() int {
+	q.mu.Lock()
+	for q.count == 0 {
+		q.notEmpty.Wait()
+	}
+	v := q.buf[q.head]
+	q.head = (q.head + 1) % len(q.buf)
+	q.count--
+	q.mu.Unlock()
+	q.notFull.Signal()
+	return v
+}
+
+// BenchmarkBlockingQueue moves b.N values from producers to consumers through a queue of 64,
+// comparing BlockingQueue with the mutex and sync.Cond baseline
+func BenchmarkBlockingQueue(b *testing.B) {
+	for _, workers := range []int{1, 4, 16} {
+		b.Run(fmt.Sprintf("BlockingQueue/workers=%d", workers), func(b *testing.B) {
+			ctx := context.Background()
+			q := NewBlockingQueue[int](64)
+			runProducersConsumers(b.N, workers,
+				func(v int) { q.Put(ctx, v) },
+				func() { q.Take(ctx) })
+		})
+		b.Run(fmt.Sprintf("Mutex/workers=%d", workers), func(b *testing.B) {
+			q := newCondQueue(64)
+			runProducersConsumers(b.N, workers, q.put, func() { q.take() })
+		})
+	}
+}
+
+// runProducersConsumers starts workers producers and workers consumers and moves about n values
+func runProducersConsumers(n, workers int, put func(int), take func()) {
+	perWorker := n/workers + 1
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Go(func() {
+			for i := range perWorker {
+				put(i)
+			}
+		})
+		wg.Go(func() {
+			for range perWorker {
+				take()
+			}
+		})
+	}
+	wg.Wait()
+}
@@ -1,5 +1,7 @@
 package data
 
+import "cmp"
+
 // Bubblesort sorts a slice of integers in ascending order using the bubble sort algorithm.
 //
 // 🔹 items: slice of integers to be sorted
@@ -12,6 +14,24 @@ package data
 // Space Complexity: O(1) (in-place sorting)
 // ✅ Best for small datasets or educational purposes; not efficient for large datasets.
 func Bubblesort(items []int) {
+	BubblesortOrdered(items)
+}
+
+// BubblesortOrdered is the generic form of Bubblesort.
+// It sorts any slice whose element type supports the < operator (ints, floats, strings, ...).
+func BubblesortOrdered[T cmp.Ordered](items []T) {
+	BubblesortFunc(items, cmp.Compare[T])
+}
+
+// BubblesortFunc sorts items in ascending order as determined by the compare function.
+//
+// 🔹 items: slice of any type to be sorted
+// 🔹 compare: returns a negative number when a < b, zero when a == b and a positive number when a > b
+//
+// Use it for structs, e.g. sorting customers by name:
+//
+//	data.BubblesortFunc(customers, func(a, b Customer) int { return strings.Compare(a.Name, b.Name) })
+func BubblesortFunc[T any](items []T, compare func(a, b T) int) {
 	n := len(items)
 	sorted := false
 
@@ -21,7 +41,7 @@ func Bubblesort(items []int) {
 
 		// Compare adjacent elements
 		for i := 0; i < n-1; i++ {
-			if items[i] > items[i+1] {
+			if compare(items[i], items[i+1]) > 0 {
 				// Swap if elements are in wrong order
 				items[i], items[i+1] = items[i+1], items[i]
 				swapped = true
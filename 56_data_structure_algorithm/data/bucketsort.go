@@ -0,0 +1,127 @@
+package data
+
+import (
+	"cmp"
+	"math"
+
+	"golang.org/x/exp/constraints"
+)
+
+// bucketInsertionLimit is the largest bucket Bucketsort sorts with Insertionsort.
+const bucketInsertionLimit = 32
+
+// Bucketsort sorts a slice of floats in ascending order using the Bucket Sort algorithm.
+//
+// 🔹 items: slice of float64 to be sorted
+//
+// Bucket Sort splits the range [min, max] into n equally wide buckets,
+// drops every element into the bucket its value falls in, and sorts each
+// (usually tiny) bucket with Insertionsort. Reading the buckets in order gives the sorted slice.
+// Buckets that end up large, because the values are skewed, are sorted with Mergesort instead.
+//
+// Time Complexity:
+// - Average case: O(n) for uniformly distributed values
+// - Worst case: O(n log n) when most values land in the same bucket
+// Space Complexity: O(n)
+//
+// NaNs are placed first and infinities at the ends, the same order as slices.Sort.
+func Bucketsort(items []float64) {
+	BucketsortFloats(items)
+}
+
+// BucketsortFloats is the generic form of Bucketsort for any float type.
+func BucketsortFloats[T constraints.Float](items []T) {
+	BucketsortFunc(items, func(v T) float64 { return float64(v) })
+}
+
+// BucketsortFunc sorts items in ascending order of key using Bucket Sort.
+//
+// 🔹 items: slice of any type to be sorted
+// 🔹 key: maps an element to a float64 key, e.g. a price or a score
+//
+// The sort is stable.
+func BucketsortFunc[T any](items []T, key func(T) float64) {
+	n := len(items)
+	if n < 2 {
+		return
+	}
+
+	// Find the range of the finite keys
+	keys := make([]float64, n)
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for i, item := range items {
+		keys[i] = key(item)
+		if !math.IsInf(keys[i], 0) && !math.IsNaN(keys[i]) {
+			lo = min(lo, keys[i])
+			hi = max(hi, keys[i])
+		}
+	}
+
+	// Buckets: 0 holds the NaNs, 1..n the values from lo to hi, -Inf joins 1 and +Inf joins n
+	bucketOf := func(k float64) int {
+		switch {
+		case math.IsNaN(k):
+			return 0
+		case k <= lo:
+			return 1
+		case k >= hi:
+			return n
+		}
+		// Halve before subtracting: hi-lo overflows to +Inf when the keys span
+		// more than the float64 range, e.g. -math.MaxFloat64 and math.MaxFloat64
+		pos := (k/2 - lo/2) / (hi/2 - lo/2)
+
+		// Rounding can still land a hair outside [0, 1]
+		return clamp(1+int(pos*float64(n-1)), 1, n)
+	}
+
+	counts := make([]int, n+2)
+	buckets := make([]int, n)
+	for i, k := range keys {
+		buckets[i] = bucketOf(k)
+		counts[buckets[i]+1]++
+	}
+
+	// counts[b] becomes the start of bucket b
+	for b := 1; b < len(counts); b++ {
+		counts[b] += counts[b-1]
+	}
+	starts := append([]int(nil), counts...)
+
+	// Scatter the elements (and their keys) into their buckets in input order
+	out := make([]T, n)
+	outKeys := make([]float64, n)
+	for i, b := range buckets {
+		out[counts[b]] = items[i]
+		outKeys[counts[b]] = keys[i]
+		counts[b]++
+	}
+
+	// Sort every bucket; cmp.Compare orders -Inf < finite < +Inf
+	type keyed struct {
+		item T
+		key  float64
+	}
+	var bucket []keyed
+	for b := 1; b <= n; b++ {
+		lo, hi := starts[b], starts[b+1]
+		if hi-lo < 2 {
+			continue
+		}
+		bucket = bucket[:0]
+		for i := lo; i < hi; i++ {
+			bucket = append(bucket, keyed{out[i], outKeys[i]})
+		}
+		byKey := func(a, b keyed) int { return cmp.Compare(a.key, b.key) }
+		if len(bucket) <= bucketInsertionLimit {
+			InsertionsortFunc(bucket, byKey)
+		} else {
+			MergesortFunc(bucket, byKey)
+		}
+		for i, kv := range bucket {
+			out[lo+i] = kv.item
+		}
+	}
+
+	copy(items, out)
+}
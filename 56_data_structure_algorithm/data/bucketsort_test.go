@@ -0,0 +1,95 @@
+package data
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestBucketsort(t *testing.T) {
+	inf := math.Inf(1)
+	tests := [][]float64{
+		{},
+		{1},
+		{2, 1},
+		{3, 3, 3, 3},
+		{0.5, 0.25, 0.75, 0, 1},
+		{-1.5, 2.5, -3, 0, 1e-300, -1e-300},
+		{math.NaN(), 1, inf, -inf, math.NaN(), 0, -inf},
+		{inf, -inf, inf},
+		// Keys spanning more than the float64 range used to overflow hi-lo to +Inf
+		{-math.MaxFloat64, 0, math.MaxFloat64, 1e308},
+		{math.MaxFloat64, -math.MaxFloat64, math.MaxFloat64 / 2, -math.MaxFloat64 / 3, 0, 1, -1},
+		{math.SmallestNonzeroFloat64, 0, 3 * math.SmallestNonzeroFloat64, 4 * math.SmallestNonzeroFloat64, 5 * math.SmallestNonzeroFloat64},
+	}
+	for _, input := range tests {
+		got := slices.Clone(input)
+		Bucketsort(got)
+
+		want := slices.Clone(input)
+		slices.Sort(want)
+		// NaN != NaN, so compare the printed form
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("Bucketsort(%v) = %v, want %v", input, got, want)
+		}
+	}
+}
+
+func TestBucketsortShapes(t *testing.T) {
+	for _, kind := range inputKinds {
+		for _, n := range testSizes {
+			t.Run(fmt.Sprintf("%s/%d", kind.name, n), func(t *testing.T) {
+				input := kind.gen(newRand(), n)
+				got := slices.Clone(input)
+				bucketsortInts(got)
+				checkSorted(t, input, got)
+			})
+		}
+	}
+}
+
+func TestBucketsortFuncStable(t *testing.T) {
+	keys := inputKinds[0].gen(newRand(), 10_000)
+	records := make([]record, len(keys))
+	for i, k := range keys {
+		records[i] = record{key: k % 1000, seq: i}
+	}
+
+	BucketsortFunc(records, func(r record) float64 { return float64(r.key) })
+
+	for i := 1; i < len(records); i++ {
+		prev, cur := records[i-1], records[i]
+		if prev.key > cur.key || prev.key == cur.key && prev.seq > cur.seq {
+			t.Fatalf("index %d: %v after %v", i, cur, prev)
+		}
+	}
+}
+
+// BenchmarkBucketsort compares Bucketsort on uniformly distributed floats with the comparison sorts
+func BenchmarkBucketsort(b *testing.B) {
+	sorts := []struct {
+		name string
+		sort func([]float64)
+	}{
+		{"Bucketsort", Bucketsort},
+		{"Quicksort", func(a []float64) { QuicksortOrdered(a) }},
+		{"Combsort", CombsortOrdered[float64]},
+	}
+	for _, s := range sorts {
+		for _, n := range []int{1e3, 1e5, 1e6} {
+			b.Run(fmt.Sprintf("%s/n=%d", s.name, n), func(b *testing.B) {
+				r := newRand()
+				input := make([]float64, n)
+				for i := range input {
+					input[i] = r.Float64()
+				}
+				items := make([]float64, n)
+				for b.Loop() {
+					copy(items, input)
+					s.sort(items)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package data
+
+// EvictionReason tells an EvictionFunc why an entry left a cache.
+type EvictionReason int
+
+const (
+	// EvictedCapacity means the cache was full and the entry made room for a new one
+	EvictedCapacity EvictionReason = iota
+	// EvictedExpired means the entry outlived its time to live (TTLCache only)
+	EvictedExpired
+)
+
+// String returns the reason as a short word, e.g. "capacity"
+func (r EvictionReason) String() string {
+	switch r {
+	case EvictedCapacity:
+		return "capacity"
+	case EvictedExpired:
+		return "expired"
+	default:
+		return "unknown"
+	}
+}
+
+// EvictionFunc is called for every entry a cache evicts on its own.
+// It is not called for entries removed with Delete or replaced with Put.
+//
+// ⚠️ It runs after the cache lock is released, so it may use the cache,
+// but it may run concurrently with other cache operations.
+type EvictionFunc[K comparable, V any] func(key K, value V, reason EvictionReason)
+
+// CacheStats counts how a cache has been used since it was created.
+type CacheStats struct {
+	Hits      uint64 `json:"hits"`      // Get calls that found a value
+	Misses    uint64 `json:"misses"`    // Get calls that found nothing (or an expired value)
+	Evictions uint64 `json:"evictions"` // Entries evicted for capacity or expiry
+}
+
+// HitRate returns Hits / (Hits + Misses), or 0 before the first Get.
+func (s CacheStats) HitRate() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+// evicted is an entry waiting for the EvictionFunc to be called outside the lock
+type evicted[K comparable, V any] struct {
+	key    K
+	value  V
+	reason EvictionReason
+}
+
+// notifyEvicted calls onEvict for every entry in list; onEvict may be nil.
+func notifyEvicted[K comparable, V any](onEvict EvictionFunc[K, V], list []evicted[K, V]) {
+	if onEvict == nil {
+		return
+	}
+	for _, e := range list {
+		onEvict(e.key, e.value, e.reason)
+	}
+}
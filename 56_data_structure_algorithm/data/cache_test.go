@@ -0,0 +1,243 @@
+package data
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// cache is what the three caches have in common
+type cache[K comparable, V any] interface {
+	Get(key K) (V, bool)
+	Peek(key K) (V, bool)
+	Put(key K, value V)
+	Delete(key K) bool
+	Len() int
+	Stats() CacheStats
+}
+
+// evictionLog records the calls of an EvictionFunc
+type evictionLog struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (l *evictionLog) onEvict(key string, value int, reason EvictionReason) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, fmt.Sprintf("%s=%d %s", key, value, reason))
+}
+
+func (l *evictionLog) take() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	events := l.events
+	l.events = nil
+	return events
+}
+
+// checkEvents fails the test if log did not see exactly want since the last check
+func checkEvents(t *testing.T, log *evictionLog, want ...string) {
+	t.Helper()
+	if got := log.take(); !slices.Equal(got, want) {
+		t.Fatalf("evicted %q, want %q", got, want)
+	}
+}
+
+func TestLRUCache(t *testing.T) {
+	var log evictionLog
+	c := NewLRUCache(2, log.onEvict)
+
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.Get("a") // b is now the least recently used
+	c.Put("c", 3)
+	checkEvents(t, &log, "b=2 capacity")
+
+	// Peek does not count as a use, so a stays the least recently used
+	c.Peek("a")
+	c.Get("c")
+	c.Put("d", 4)
+	checkEvents(t, &log, "a=1 capacity")
+
+	// Replacing a value and deleting a key do not call the EvictionFunc
+	c.Put("c", 30)
+	if !c.Delete("d") || c.Delete("d") {
+		t.Fatal("Delete(d) should report true once, then false")
+	}
+	checkEvents(t, &log)
+
+	if v, ok := c.Get("c"); !ok || v != 30 || c.Len() != 1 {
+		t.Fatalf("Get(c) = %d, %v with Len %d, want 30, true with Len 1", v, ok, c.Len())
+	}
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("evicted key a is still cached")
+	}
+
+	want := CacheStats{Hits: 3, Misses: 1, Evictions: 2}
+	if got := c.Stats(); got != want {
+		t.Fatalf("Stats = %+v, want %+v", got, want)
+	}
+	if got := c.Stats().HitRate(); got != 0.75 {
+		t.Fatalf("HitRate = %v, want 0.75", got)
+	}
+}
+
+func TestLFUCache(t *testing.T) {
+	var log evictionLog
+	c := NewLFUCache(3, log.onEvict)
+
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.Put("c", 3)
+	c.Get("a")
+	c.Get("a")
+	c.Get("b")
+	// Uses: a 3, b 2, c 1
+	c.Put("d", 4)
+	checkEvents(t, &log, "c=3 capacity")
+
+	// d (1 use) is the least frequently used now
+	c.Put("e", 5)
+	checkEvents(t, &log, "d=4 capacity")
+
+	// Deleting e leaves a and b; then give a, b and f the same count of 3
+	c.Delete("e")
+	c.Put("f", 6)
+	c.Get("f")
+	c.Get("f")
+	c.Get("b")
+	// Ties go to the least recently used: a reached 3 uses first
+	c.Put("g", 7)
+	checkEvents(t, &log, "a=1 capacity")
+
+	for _, key := range []string{"b", "f", "g"} {
+		if _, ok := c.Peek(key); !ok {
+			t.Fatalf("%s should still be cached", key)
+		}
+	}
+	if got, want := c.Stats(), (CacheStats{Hits: 6, Misses: 0, Evictions: 3}); got != want {
+		t.Fatalf("Stats = %+v, want %+v", got, want)
+	}
+}
+
+func TestTTLCache(t *testing.T) {
+	var log evictionLog
+	c := NewTTLCache(2, time.Hour, log.onEvict)
+	defer c.Close()
+
+	c.PutWithTTL("short", 1, 10*time.Millisecond)
+	c.Put("long", 2)
+	if _, ok := c.Get("short"); !ok {
+		t.Fatal("short expired too early")
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	// Peek does not return expired values either, but leaves them to Get and the janitor
+	if _, ok := c.Peek("short"); ok {
+		t.Fatal("Peek returned an expired value")
+	}
+	if _, ok := c.Get("short"); ok {
+		t.Fatal("Get returned an expired value")
+	}
+	checkEvents(t, &log, "short=1 expired")
+
+	// When full, the least recently used entry goes
+	c.Put("a", 3)
+	c.Put("b", 4)
+	checkEvents(t, &log, "long=2 capacity")
+
+	want := CacheStats{Hits: 1, Misses: 1, Evictions: 2}
+	if got := c.Stats(); got != want {
+		t.Fatalf("Stats = %+v, want %+v", got, want)
+	}
+}
+
+func TestTTLCacheJanitor(t *testing.T) {
+	var log evictionLog
+	c := NewTTLCache(10, 20*time.Millisecond, log.onEvict)
+	c.Put("a", 1)
+	c.Put("b", 2)
+
+	// The janitor sweeps every 10ms, so both are gone well within 200ms without any Get
+	deadline := time.Now().Add(200 * time.Millisecond)
+	for c.Len() > 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if c.Len() != 0 {
+		t.Fatalf("Len = %d, the janitor did not remove the expired entries", c.Len())
+	}
+	events := log.take()
+	slices.Sort(events)
+	if !slices.Equal(events, []string{"a=1 expired", "b=2 expired"}) {
+		t.Fatalf("evicted %q", events)
+	}
+
+	c.Close()
+	c.Close() // safe to call twice
+	c.Put("c", 3)
+	if v, ok := c.Get("c"); !ok || v != 3 {
+		t.Fatal("the cache stopped working after Close")
+	}
+}
+
+// TestCachesConcurrent hammers every cache from many goroutines (use -race).
+// The EvictionFunc uses the cache too, which must not deadlock.
+func TestCachesConcurrent(t *testing.T) {
+	caches := []struct {
+		name string
+		new  func(onEvict EvictionFunc[int, int]) cache[int, int]
+	}{
+		{"LRU", func(f EvictionFunc[int, int]) cache[int, int] { return NewLRUCache(64, f) }},
+		{"LFU", func(f EvictionFunc[int, int]) cache[int, int] { return NewLFUCache(64, f) }},
+		{"TTL", func(f EvictionFunc[int, int]) cache[int, int] {
+			c := NewTTLCache(64, time.Millisecond, f)
+			t.Cleanup(c.Close)
+			return c
+		}},
+	}
+	for _, tc := range caches {
+		t.Run(tc.name, func(t *testing.T) {
+			var c cache[int, int]
+			c = tc.new(func(key, value int, reason EvictionReason) {
+				if key != value {
+					t.Errorf("evicted %d=%d", key, value)
+				}
+				c.Peek(key)
+			})
+
+			const goroutines, ops = 8, 5_000
+			var wg sync.WaitGroup
+			for g := range goroutines {
+				wg.Go(func() {
+					for i := range ops {
+						k := (g*ops + i*7) % 200
+						switch i % 4 {
+						case 0, 1:
+							if v, ok := c.Get(k); ok && v != k {
+								t.Errorf("Get(%d) = %d", k, v)
+							}
+						case 2:
+							c.Put(k, k)
+						case 3:
+							if i%40 == 3 {
+								c.Delete(k)
+							}
+						}
+					}
+				})
+			}
+			wg.Wait()
+
+			if n := c.Len(); n > 64 {
+				t.Fatalf("Len = %d, more than the capacity", n)
+			}
+			s := c.Stats()
+			if s.Hits+s.Misses != goroutines*ops/2 {
+				t.Fatalf("Stats = %+v, want %d lookups", s, goroutines*ops/2)
+			}
+		})
+	}
+}
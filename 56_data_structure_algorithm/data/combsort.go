@@ -1,5 +1,7 @@
 package data
 
+import "cmp"
+
 // Combsort sorts a slice of integers in ascending order using the Comb Sort algorithm.
 //
 // 🔹 items: slice of integers to be sorted
@@ -12,13 +14,26 @@ package data
 // Space Complexity: O(1) (in-place sorting)
 // ✅ More efficient than Bubble Sort, but less common than quicksort or mergesort.
 func Combsort(items []int) {
+	CombsortOrdered(items)
+}
+
+// CombsortOrdered is the generic form of Combsort for any ordered element type.
+func CombsortOrdered[T cmp.Ordered](items []T) {
+	CombsortFunc(items, cmp.Compare[T])
+}
+
+// CombsortFunc sorts items in ascending order as determined by the compare function.
+//
+// 🔹 items: slice of any type to be sorted
+// 🔹 compare: returns a negative number when a < b, zero when a == b and a positive number when a > b
+func CombsortFunc[T any](items []T, compare func(a, b T) int) {
 	n := len(items)
-	gap := n          // Initial gap size is the length of the slice
-	shrink := 1.3     // Shrink factor determines how the gap decreases
-	swapped := true   // Flag to track if any swaps occurred in a pass
+	gap := n        // Initial gap size is the length of the slice
+	shrink := 1.3   // Shrink factor determines how the gap decreases
+	swapped := true // Flag to track if any swaps occurred in a pass
 
-	// Continue iterations until no swaps occur
-	for swapped {
+	// Continue iterations until the gap has shrunk to 1 and a full pass made no swaps
+	for gap > 1 || swapped {
 		swapped = false
 
 		// Shrink the gap for the next iteration
@@ -29,7 +44,7 @@ func Combsort(items []int) {
 
 		// Compare elements with the current gap
 		for i := 0; i+gap < n; i++ {
-			if items[i] > items[i+gap] {
+			if compare(items[i], items[i+gap]) > 0 {
 				// Swap elements if they are in wrong order
 				items[i], items[i+gap] = items[i+gap], items[i]
 				swapped = true
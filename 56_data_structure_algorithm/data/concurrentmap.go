@@ -0,0 +1,141 @@
+package data
+
+import (
+	"hash/maphash"
+	"iter"
+	"runtime"
+	"sync"
+)
+
+// ConcurrentMap is a map that is safe for concurrent use, split into independently locked shards.
+//
+// A single sync.RWMutex around one map makes every writer wait for every other goroutine.
+// Here each key is hashed to one of several shards and only that shard is locked,
+// so goroutines working on different keys rarely get in each other's way.
+// Each shard uses a sync.RWMutex, so any number of readers can share a shard.
+//
+// Time Complexity: Load, Store, Delete O(1) on average
+type ConcurrentMap[K comparable, V any] struct {
+	seed   maphash.Seed
+	shards []mapShard[K, V]
+}
+
+type mapShard[K comparable, V any] struct {
+	mu sync.RWMutex
+	m  map[K]V
+}
+
+// NewConcurrentMap creates an empty map with 4 shards per CPU.
+func NewConcurrentMap[K comparable, V any]() *ConcurrentMap[K, V] {
+	return NewConcurrentMapShards[K, V](4 * runtime.GOMAXPROCS(0))
+}
+
+// NewConcurrentMapShards creates an empty map with the given number of shards (at least 1).
+func NewConcurrentMapShards[K comparable, V any](shards int) *ConcurrentMap[K, V] {
+	cm := &ConcurrentMap[K, V]{
+		seed:   maphash.MakeSeed(),
+		shards: make([]mapShard[K, V], max(shards, 1)),
+	}
+	for i := range cm.shards {
+		cm.shards[i].m = make(map[K]V)
+	}
+	return cm
+}
+
+// shard returns the shard responsible for key.
+func (cm *ConcurrentMap[K, V]) shard(key K) *mapShard[K, V] {
+	h := maphash.Comparable(cm.seed, key)
+	return &cm.shards[h%uint64(len(cm.shards))]
+}
+
+// Load returns the value stored for key; ok is false if the key is not present.
+func (cm *ConcurrentMap[K, V]) Load(key K) (value V, ok bool) {
+	s := cm.shard(key)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	value, ok = s.m[key]
+	return value, ok
+}
+
+// Store sets the value for key.
+func (cm *ConcurrentMap[K, V]) Store(key K, value V) {
+	s := cm.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.m[key] = value
+}
+
+// LoadOrStore returns the existing value for key if present (loaded is true).
+// Otherwise it stores value and returns it (loaded is false). The check and the store are atomic.
+func (cm *ConcurrentMap[K, V]) LoadOrStore(key K, value V) (actual V, loaded bool) {
+	s := cm.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if old, ok := s.m[key]; ok {
+		return old, true
+	}
+	s.m[key] = value
+	return value, false
+}
+
+// Update atomically replaces the value for key with fn(old, ok), e.g. to increment a counter.
+// fn runs while the shard is locked, so it must be quick and must not use the map.
+func (cm *ConcurrentMap[K, V]) Update(key K, fn func(old V, ok bool) V) V {
+	s := cm.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	old, ok := s.m[key]
+	v := fn(old, ok)
+	s.m[key] = v
+	return v
+}
+
+// Delete removes key and reports whether it was present.
+func (cm *ConcurrentMap[K, V]) Delete(key K) bool {
+	s := cm.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.m[key]
+	delete(s.m, key)
+	return ok
+}
+
+// Len returns the number of keys. Under concurrent use it is only a snapshot.
+func (cm *ConcurrentMap[K, V]) Len() int {
+	n := 0
+	for i := range cm.shards {
+		s := &cm.shards[i]
+		s.mu.RLock()
+		n += len(s.m)
+		s.mu.RUnlock()
+	}
+	return n
+}
+
+// All returns an iterator over all keys and values, in no particular order.
+// Each shard is copied under its read lock and iterated without holding it,
+// so the loop body may safely use the map (but may not see concurrent changes).
+func (cm *ConcurrentMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		type entry struct {
+			key   K
+			value V
+		}
+		var entries []entry
+		for i := range cm.shards {
+			s := &cm.shards[i]
+			s.mu.RLock()
+			entries = entries[:0]
+			for k, v := range s.m {
+				entries = append(entries, entry{k, v})
+			}
+			s.mu.RUnlock()
+
+			for _, e := range entries {
+				if !yield(e.key, e.value) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package data
+
+import (
+	"fmt"
+	"maps"
+	"sync"
+	"testing"
+)
+
+func TestConcurrentMap(t *testing.T) {
+	for _, shards := range []int{0, 1, 7, 64} {
+		t.Run(fmt.Sprint(shards), func(t *testing.T) {
+			m := NewConcurrentMapShards[string, int](shards)
+			if _, ok := m.Load("a"); ok {
+				t.Fatal("Load on an empty map found a value")
+			}
+
+			m.Store("a", 1)
+			m.Store("b", 2)
+			m.Store("a", 3)
+			if v, ok := m.Load("a"); !ok || v != 3 {
+				t.Fatalf("Load(a) = %d, %v, want 3, true", v, ok)
+			}
+			if v, loaded := m.LoadOrStore("b", 9); !loaded || v != 2 {
+				t.Fatalf("LoadOrStore(b) = %d, %v, want 2, true", v, loaded)
+			}
+			if v, loaded := m.LoadOrStore("c", 4); loaded || v != 4 {
+				t.Fatalf("LoadOrStore(c) = %d, %v, want 4, false", v, loaded)
+			}
+			if v := m.Update("c", func(old int, ok bool) int { return old + 10 }); v != 14 {
+				t.Fatalf("Update(c) = %d, want 14", v)
+			}
+			if !m.Delete("b") || m.Delete("b") {
+				t.Fatal("Delete(b) should report true once, then false")
+			}
+
+			want := map[string]int{"a": 3, "c": 14}
+			if got := maps.Collect(m.All()); !maps.Equal(got, want) || m.Len() != 2 {
+				t.Fatalf("All = %v, Len = %d, want %v", got, m.Len(), want)
+			}
+		})
+	}
+}
+
+// TestConcurrentMapStress updates, loads and deletes from many goroutines at once (use -race)
+func TestConcurrentMapStress(t *testing.T) {
+	const goroutines, keys, rounds = 16, 100, 200
+	m := NewConcurrentMapShards[int, int](8)
+
+	var wg sync.WaitGroup
+	winners := make([]int, keys)
+	var winnersMu sync.Mutex
+	for g := range goroutines {
+		wg.Go(func() {
+			for k := range keys {
+				// Only one goroutine may store each key first
+				if _, loaded := m.LoadOrStore(-k-1, g); !loaded {
+					winnersMu.Lock()
+					winners[k]++
+					winnersMu.Unlock()
+				}
+			}
+			for range rounds {
+				for k := range keys {
+					m.Update(k, func(old int, ok bool) int { return old + 1 })
+					m.Load(k)
+				}
+			}
+			// Iterating while others write must be safe, and may use the map
+			for k := range m.All() {
+				m.Load(k)
+			}
+		})
+	}
+	wg.Wait()
+
+	for k := range keys {
+		if winners[k] != 1 {
+			t.Fatalf("LoadOrStore stored key %d %d times", -k-1, winners[k])
+		}
+		if v, _ := m.Load(k); v != goroutines*rounds {
+			t.Fatalf("key %d = %d after %d increments", k, v, goroutines*rounds)
+		}
+	}
+
+	for g := range goroutines {
+		wg.Go(func() {
+			for k := g; k < keys; k += goroutines {
+				m.Delete(k)
+				m.Delete(-k - 1)
+			}
+		})
+	}
+	wg.Wait()
+	if m.Len() != 0 {
+		t.Fatalf("Len = %d after deleting every key", m.Len())
+	}
+}
+
+// mutexMap is the single-mutex baseline for ConcurrentMap: one RWMutex around one map
+type mutexMap struct {
+	mu sync.RWMutex
+	m  map[int]int
+}
+
+func (m *mutexMap) load(k int) (int, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	v, ok := m.m[k]
+	return v, ok
+}
+
+func (m *mutexMap) store(k, v int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.m[k] = v
+}
+
+// BenchmarkConcurrentMap compares ConcurrentMap with a single RWMutex around one map,
+// from GOMAXPROCS goroutines, for workloads with 10%, 50% and 100% writes.
+// Run it with -cpu 1,4,16 to see the effect of sharding.
+func BenchmarkConcurrentMap(b *testing.B) {
+	const keys = 1 << 12
+	for _, writePercent := range []int{10, 50, 100} {
+		b.Run(fmt.Sprintf("Sharded/writes=%d%%", writePercent), func(b *testing.B) {
+			m := NewConcurrentMap[int, int]()
+			for k := range keys {
+				m.Store(k, k)
+			}
+			b.RunParallel(func(pb *testing.PB) {
+				for i := 0; pb.Next(); i++ {
+					k := (i * 2654435761) % keys
+					if i%100 < writePercent {
+						m.Store(k, i)
+					} else {
+						m.Load(k)
+					}
+				}
+			})
+		})
+		b.Run(fmt.Sprintf("Mutex/writes=%d%%", writePercent), func(b *testing.B) {
+			m := &mutexMap{m: make(map[int]int)}
+			for k := range keys {
+				m.store(k, k)
+			}
+			b.RunParallel(func(pb *testing.PB) {
+				for i := 0; pb.Next(); i++ {
+					k := (i * 2654435761) % keys
+					if i%100 < writePercent {
+						m.store(k, i)
+					} else {
+						m.load(k)
+					}
+				}
+			})
+		})
+	}
+}
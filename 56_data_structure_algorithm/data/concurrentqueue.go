@@ -0,0 +1,162 @@
+package data
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Queue is an unbounded FIFO queue that is safe for many producers and many consumers (MPMC).
+//
+// It is the straightforward design from 53_data_race: one sync.Mutex guards a ring buffer,
+// so only one goroutine touches the queue at a time. The buffer doubles when it is full.
+//
+// Time Complexity: Enqueue amortised O(1), Dequeue O(1)
+//
+// ✅ The zero value is an empty queue ready to use. Simple, and usually fast enough;
+// compare it with LockFreeQueue under heavy contention.
+type Queue[T any] struct {
+	mu    sync.Mutex
+	buf   []T
+	head  int // index of the oldest element
+	count int // number of elements
+}
+
+// Enqueue adds value at the back of the queue.
+func (q *Queue[T]) Enqueue(value T) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.count == len(q.buf) {
+		q.grow()
+	}
+	q.buf[(q.head+q.count)%len(q.buf)] = value
+	q.count++
+}
+
+// Dequeue removes and returns the value at the front of the queue.
+// ok is false if the queue is empty; Dequeue never blocks.
+func (q *Queue[T]) Dequeue() (value T, ok bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.count == 0 {
+		return value, false
+	}
+	value = q.buf[q.head]
+	var zero T
+	q.buf[q.head] = zero // let the garbage collector reclaim removed pointers
+	q.head = (q.head + 1) % len(q.buf)
+	q.count--
+	return value, true
+}
+
+// Len returns the number of values in the queue.
+func (q *Queue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.count
+}
+
+// grow doubles the ring buffer, unwrapping it so the oldest element is at index 0.
+func (q *Queue[T]) grow() {
+	buf := make([]T, max(2*len(q.buf), 8))
+	n := copy(buf, q.buf[q.head:])
+	copy(buf[n:], q.buf[:q.head])
+	q.buf = buf
+	q.head = 0
+}
+
+// LockFreeQueue is an unbounded MPMC FIFO queue that uses no locks at all:
+// the Michael-Scott queue (1996).
+//
+// The queue is a singly linked list with a dummy node at the front.
+// Goroutines never wait for each other; they read the head or tail pointer,
+// prepare their change and publish it with a single atomic compare-and-swap (CAS).
+// If another goroutine got there first, the CAS fails and they simply retry.
+// A goroutine that sees the tail lagging behind helps to move it forward.
+//
+// Time Complexity: Enqueue and Dequeue O(1) (plus retries under contention)
+//
+// ✅ Go's garbage collector keeps a node alive while any goroutine still points to it,
+// which rules out the ABA problem that complicates this algorithm in C.
+type LockFreeQueue[T any] struct {
+	head atomic.Pointer[lfNode[T]] // the dummy node; the front value is head.next
+	tail atomic.Pointer[lfNode[T]] // the last node, or (briefly) the one before it
+	len  atomic.Int64
+}
+
+type lfNode[T any] struct {
+	value T
+	next  atomic.Pointer[lfNode[T]]
+}
+
+// NewLockFreeQueue creates an empty lock-free queue.
+func NewLockFreeQueue[T any]() *LockFreeQueue[T] {
+	q := &LockFreeQueue[T]{}
+	dummy := &lfNode[T]{}
+	q.head.Store(dummy)
+	q.tail.Store(dummy)
+	return q
+}
+
+// Enqueue adds value at the back of the queue.
+func (q *LockFreeQueue[T]) Enqueue(value T) {
+	n := &lfNode[T]{value: value}
+	for {
+		tail := q.tail.Load()
+		next := tail.next.Load()
+		if tail != q.tail.Load() {
+			// The tail moved while we were reading it: start over
+			continue
+		}
+		if next != nil {
+			// Another Enqueue linked a node but has not moved the tail yet: help it
+			q.tail.CompareAndSwap(tail, next)
+			continue
+		}
+		// 1️⃣ Link the new node after the last one
+		if tail.next.CompareAndSwap(nil, n) {
+			// 2️⃣ Swing the tail to it; if this fails, someone else already helped
+			q.tail.CompareAndSwap(tail, n)
+			q.len.Add(1)
+			return
+		}
+	}
+}
+
+// Dequeue removes and returns the value at the front of the queue.
+// ok is false if the queue is empty; Dequeue never blocks.
+func (q *LockFreeQueue[T]) Dequeue() (value T, ok bool) {
+	for {
+		head := q.head.Load()
+		tail := q.tail.Load()
+		next := head.next.Load()
+		if head != q.head.Load() {
+			continue
+		}
+		if next == nil {
+			// Only the dummy node is left
+			return value, false
+		}
+		if head == tail {
+			// The tail is lagging behind a node that is already linked: help it
+			q.tail.CompareAndSwap(tail, next)
+			continue
+		}
+		// Read the value before the CAS: afterwards next becomes the dummy node.
+		// The dummy keeps its value until the next Dequeue; clearing it here would race
+		// with other goroutines still reading it before their CAS fails.
+		value = next.value
+		if q.head.CompareAndSwap(head, next) {
+			q.len.Add(-1)
+			return value, true
+		}
+	}
+}
+
+// Len returns the number of values in the queue.
+// Under concurrent use it is only a snapshot.
+func (q *LockFreeQueue[T]) Len() int {
+	// A Dequeue can be counted just before the Enqueue it took from
+	return max(int(q.len.Load()), 0)
+}
@@ -0,0 +1,192 @@
+package data
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+)
+
+// fifo is what Queue and LockFreeQueue have in common
+type fifo[T any] interface {
+	Enqueue(value T)
+	Dequeue() (T, bool)
+	Len() int
+}
+
+// queueKinds are the MPMC queues under test; Queue is the single-mutex baseline
+var queueKinds = []struct {
+	name string
+	new  func() fifo[int]
+}{
+	{"Mutex", func() fifo[int] { return &Queue[int]{} }},
+	{"LockFree", func() fifo[int] { return NewLockFreeQueue[int]() }},
+}
+
+func TestQueueFIFO(t *testing.T) {
+	for _, k := range queueKinds {
+		t.Run(k.name, func(t *testing.T) {
+			q := k.new()
+			if _, ok := q.Dequeue(); ok {
+				t.Fatal("Dequeue on an empty queue returned a value")
+			}
+
+			// Interleave enqueues and dequeues so the ring buffer wraps around and grows
+			next, want := 0, 0
+			for round := range 50 {
+				for range round {
+					q.Enqueue(next)
+					next++
+				}
+				for range round / 2 {
+					v, ok := q.Dequeue()
+					if !ok || v != want {
+						t.Fatalf("Dequeue = %d, %v, want %d, true", v, ok, want)
+					}
+					want++
+				}
+			}
+			if q.Len() != next-want {
+				t.Fatalf("Len = %d, want %d", q.Len(), next-want)
+			}
+			for ; want < next; want++ {
+				if v, ok := q.Dequeue(); !ok || v != want {
+					t.Fatalf("Dequeue = %d, %v, want %d, true", v, ok, want)
+				}
+			}
+			if _, ok := q.Dequeue(); ok || q.Len() != 0 {
+				t.Fatal("queue not empty after taking every value")
+			}
+		})
+	}
+}
+
+// TestQueueStress runs many producers and consumers at once (use -race).
+// Every value must come out exactly once, and each consumer must see the values
+// of any one producer in the order they were enqueued.
+func TestQueueStress(t *testing.T) {
+	const producers, consumers = 8, 8
+	perProducer := 20_000
+	if testing.Short() {
+		perProducer = 2_000
+	}
+
+	for _, k := range queueKinds {
+		t.Run(k.name, func(t *testing.T) {
+			q := k.new()
+			total := producers * perProducer
+
+			var wg sync.WaitGroup
+			for p := range producers {
+				wg.Go(func() {
+					for seq := range perProducer {
+						q.Enqueue(p*perProducer + seq)
+					}
+				})
+			}
+
+			seen := make([][]int, consumers)
+			var mu sync.Mutex
+			taken := 0
+			for c := range consumers {
+				wg.Go(func() {
+					last := make([]int, producers)
+					for i := range last {
+						last[i] = -1
+					}
+					for {
+						mu.Lock()
+						done := taken == total
+						mu.Unlock()
+						if done {
+							return
+						}
+
+						v, ok := q.Dequeue()
+						if !ok {
+							runtime.Gosched()
+							continue
+						}
+						if q.Len() < 0 {
+							t.Errorf("Len = %d", q.Len())
+						}
+						p, seq := v/perProducer, v%perProducer
+						if seq <= last[p] {
+							t.Errorf("consumer %d got seq %d of producer %d after %d", c, seq, p, last[p])
+						}
+						last[p] = seq
+						seen[c] = append(seen[c], v)
+
+						mu.Lock()
+						taken++
+						mu.Unlock()
+					}
+				})
+			}
+			wg.Wait()
+
+			count := make([]int, total)
+			for _, vs := range seen {
+				for _, v := range vs {
+					count[v]++
+				}
+			}
+			for v, n := range count {
+				if n != 1 {
+					t.Fatalf("value %d taken %d times", v, n)
+				}
+			}
+			if q.Len() != 0 {
+				t.Fatalf("Len = %d after taking every value", q.Len())
+			}
+		})
+	}
+}
+
+// BenchmarkQueue measures the throughput of enqueue/dequeue pairs from GOMAXPROCS goroutines.
+// Run it with -cpu 1,4,16 to see how each queue copes with contention.
+func BenchmarkQueue(b *testing.B) {
+	for _, k := range queueKinds {
+		b.Run(k.name, func(b *testing.B) {
+			q := k.new()
+			b.RunParallel(func(pb *testing.PB) {
+				i := 0
+				for pb.Next() {
+					q.Enqueue(i)
+					q.Dequeue()
+					i++
+				}
+			})
+		})
+	}
+}
+
+// BenchmarkQueueProducersConsumers moves b.N values from several producers to as many consumers.
+func BenchmarkQueueProducersConsumers(b *testing.B) {
+	for _, k := range queueKinds {
+		for _, workers := range []int{1, 4, 16} {
+			b.Run(fmt.Sprintf("%s/workers=%d", k.name, workers), func(b *testing.B) {
+				q := k.new()
+				var wg sync.WaitGroup
+				perWorker := b.N/workers + 1
+				for range workers {
+					wg.Go(func() {
+						for i := range perWorker {
+							q.Enqueue(i)
+						}
+					})
+					wg.Go(func() {
+						for taken := 0; taken < perWorker; {
+							if _, ok := q.Dequeue(); ok {
+								taken++
+							} else {
+								runtime.Gosched()
+							}
+						}
+					})
+				}
+				wg.Wait()
+			})
+		}
+	}
+}
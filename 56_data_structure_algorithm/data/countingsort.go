@@ -0,0 +1,84 @@
+package data
+
+import (
+	"math"
+
+	"golang.org/x/exp/constraints"
+)
+
+// countingRangeLimit is the largest key range Countingsort allocates counters for
+// before handing over to Radixsort.
+const countingRangeLimit = 1 << 24
+
+// Countingsort sorts a slice of integers in ascending order using the Counting Sort algorithm.
+//
+// 🔹 items: slice of integers to be sorted
+//
+// Counting Sort counts how often every value between the minimum and the maximum occurs,
+// turns the counts into output positions, and places each element directly at its position.
+// It never compares two elements.
+//
+// Time Complexity: O(n + k) where k = max - min + 1
+// Space Complexity: O(n + k)
+//
+// ✅ Ideal when the values span a small range (ages, ratings, HTTP status codes).
+// If the range is larger than 16 million and more than 4 times n, Radixsort is used instead.
+func Countingsort(items []int) {
+	CountingsortIntegers(items)
+}
+
+// CountingsortIntegers is the generic form of Countingsort for any integer type, signed or unsigned.
+func CountingsortIntegers[T constraints.Integer](items []T) {
+	// int(v) would wrap unsigned values above math.MaxInt64 around to negative keys.
+	// Flipping the sign bit of integerKey back keeps every value in order instead:
+	// signed values stay themselves, unsigned ones move down by 2^63.
+	CountingsortFunc(items, func(v T) int { return int(integerKey(v) ^ (1 << 63)) })
+}
+
+// CountingsortFunc sorts items in ascending order of key using Counting Sort.
+//
+// 🔹 items: slice of any type to be sorted
+// 🔹 key: maps an element to an integer key, e.g. a product category number
+//
+// The sort is stable.
+func CountingsortFunc[T any](items []T, key func(T) int) {
+	n := len(items)
+	if n < 2 {
+		return
+	}
+
+	keys := make([]int, n)
+	lo, hi := key(items[0]), key(items[0])
+	for i, item := range items {
+		keys[i] = key(item)
+		lo = min(lo, keys[i])
+		hi = max(hi, keys[i])
+	}
+
+	// hi - lo may overflow an int for extreme keys
+	span := uint64(hi) - uint64(lo)
+	if span >= countingRangeLimit && span/4 >= uint64(n) || span >= math.MaxInt32 {
+		RadixsortFunc(items, func(v T) uint64 { return integerKey(key(v)) })
+		return
+	}
+
+	counts := make([]int, span+1)
+	for _, k := range keys {
+		counts[k-lo]++
+	}
+
+	// Turn the counts into the first output position of every key
+	pos := 0
+	for k, c := range counts {
+		counts[k] = pos
+		pos += c
+	}
+
+	// Place the elements in input order, which is what keeps the sort stable
+	out := make([]T, n)
+	for i, k := range keys {
+		out[counts[k-lo]] = items[i]
+		counts[k-lo]++
+	}
+	copy(items, out)
+}
@@ -0,0 +1,397 @@
+package data
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// DefaultMemoryBudget is the chunk size used by ExternalSort when no budget is given.
+const DefaultMemoryBudget = 64 << 20 // 64 MiB
+
+// RecordReader reads one record at a time from a stream.
+type RecordReader[T any] interface {
+	// Read returns the next record and roughly how many bytes it occupied in the input.
+	// It returns io.EOF when there are no more records.
+	Read() (record T, size int, err error)
+}
+
+// RecordWriter writes records to a stream.
+type RecordWriter[T any] interface {
+	Write(record T) error
+	// Close writes anything still buffered (and any closing syntax, such as "]").
+	// It does not close the underlying io.Writer.
+	Close() error
+}
+
+// RecordFormat describes how records are laid out in a stream.
+// ExternalSort uses the same format for its input, its temporary files and its output.
+type RecordFormat[T any] interface {
+	NewReader(r io.Reader) RecordReader[T]
+	NewWriter(w io.Writer) RecordWriter[T]
+}
+
+// ExternalSortOptions configures ExternalSort.
+type ExternalSortOptions struct {
+	// MemoryBudget is roughly how many bytes of input are sorted in memory at once.
+	// Each full chunk is sorted and spilled to a temporary file. Defaults to DefaultMemoryBudget.
+	MemoryBudget int64
+
+	// TempDir is where the chunk files are created. Defaults to os.TempDir().
+	// The files are always removed before ExternalSort returns.
+	TempDir string
+}
+
+// ExternalSort sorts records that may not fit in memory, reading them from r and writing them to w.
+//
+// 🔹 r, w: input and output streams
+// 🔹 format: how records are encoded, e.g. LineFormat{}, LengthPrefixedFormat{} or JSONLinesFormat[Account]{}
+// 🔹 compare: returns a negative number when a < b, zero when a == b and a positive number when a > b
+// 🔹 opts: memory budget and temporary directory
+//
+// External Merge Sort works in two phases:
+// 1. Read records until the memory budget is used up, sort them with MergesortFunc
+// and write the sorted chunk to a temporary file. Repeat until the input is exhausted.
+// 2. Open every chunk file and k-way merge them: a Heap holds the next record of each chunk,
+// the smallest one is written out and replaced by the next record of the same chunk.
+//
+// If the whole input fits in the budget, it is sorted in memory without any temporary files.
+//
+// Time Complexity: O(n log n) comparisons, every record is read and written twice
+// Space Complexity: about MemoryBudget in memory plus one buffered reader per chunk, O(n) on disk
+//
+// ✅ Stable: equal records keep their input order.
+func ExternalSort[T any](r io.Reader, w io.Writer, format RecordFormat[T], compare func(a, b T) int, opts ExternalSortOptions) (err error) {
+	budget := opts.MemoryBudget
+	if budget <= 0 {
+		budget = DefaultMemoryBudget
+	}
+
+	var chunks []string
+	defer func() {
+		for _, name := range chunks {
+			os.Remove(name)
+		}
+	}()
+
+	in := format.NewReader(r)
+	var batch []T
+	var used int64
+	eof := false
+
+	// 1️⃣ Split the input into sorted chunks
+	for !eof {
+		batch, used = batch[:0], 0
+		for used < budget {
+			rec, size, err := in.Read()
+			if errors.Is(err, io.EOF) {
+				eof = true
+				break
+			}
+			if err != nil {
+				return fmt.Errorf("read record: %w", err)
+			}
+			batch = append(batch, rec)
+			used += int64(size)
+		}
+		MergesortFunc(batch, compare)
+
+		// Everything fit in memory: no need for temporary files
+		if eof && len(chunks) == 0 {
+			return writeRecords(format.NewWriter(w), batch)
+		}
+		if len(batch) == 0 {
+			break
+		}
+
+		name, err := spillChunk(format, opts.TempDir, batch)
+		if name != "" {
+			chunks = append(chunks, name)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	// 2️⃣ Merge the chunks
+	return mergeChunks(format, chunks, w, compare)
+}
+
+// spillChunk writes a sorted batch to a new temporary file and returns its name.
+func spillChunk[T any](format RecordFormat[T], dir string, batch []T) (string, error) {
+	f, err := os.CreateTemp(dir, "extsort-*.chunk")
+	if err != nil {
+		return "", fmt.Errorf("create chunk: %w", err)
+	}
+	defer f.Close()
+
+	if err := writeRecords(format.NewWriter(f), batch); err != nil {
+		return f.Name(), fmt.Errorf("write chunk: %w", err)
+	}
+	return f.Name(), f.Close()
+}
+
+// writeRecords writes all records and closes out.
+func writeRecords[T any](out RecordWriter[T], records []T) error {
+	for _, rec := range records {
+		if err := out.Write(rec); err != nil {
+			return err
+		}
+	}
+	return out.Close()
+}
+
+// mergeChunks k-way merges the sorted chunk files into w.
+func mergeChunks[T any](format RecordFormat[T], chunks []string, w io.Writer, compare func(a, b T) int) error {
+	// cursor is the next unread record of one chunk
+	type cursor struct {
+		rec    T
+		chunk  int
+		reader RecordReader[T]
+	}
+
+	// Ties go to the earlier chunk, which keeps the sort stable
+	h := NewHeapFunc(func(a, b cursor) int {
+		if c := compare(a.rec, b.rec); c != 0 {
+			return c
+		}
+		return a.chunk - b.chunk
+	})
+
+	for i, name := range chunks {
+		f, err := os.Open(name)
+		if err != nil {
+			return fmt.Errorf("open chunk: %w", err)
+		}
+		defer f.Close()
+
+		reader := format.NewReader(bufio.NewReader(f))
+		rec, _, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("read chunk: %w", err)
+		}
+		h.Push(cursor{rec: rec, chunk: i, reader: reader})
+	}
+
+	out := format.NewWriter(w)
+	for {
+		c, ok := h.Pop()
+		if !ok {
+			break
+		}
+		if err := out.Write(c.rec); err != nil {
+			return err
+		}
+
+		// Refill from the chunk the record came from
+		rec, _, err := c.reader.Read()
+		if errors.Is(err, io.EOF) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("read chunk: %w", err)
+		}
+		c.rec = rec
+		h.Push(c)
+	}
+	return out.Close()
+}
+
+// LineFormat stores one record per line (newline-delimited text).
+// Records are the lines without their trailing "\n" or "\r\n".
+type LineFormat struct{}
+
+// NewReader returns a reader that yields one line per record.
+func (LineFormat) NewReader(r io.Reader) RecordReader[string] {
+	return &lineReader{r: bufio.NewReader(r)}
+}
+
+// NewWriter returns a writer that ends every record with "\n".
+func (LineFormat) NewWriter(w io.Writer) RecordWriter[string] {
+	return &lineWriter{w: bufio.NewWriter(w)}
+}
+
+type lineReader struct {
+	r *bufio.Reader
+}
+
+func (lr *lineReader) Read() (string, int, error) {
+	line, err := lr.r.ReadString('\n')
+	if err != nil && !(errors.Is(err, io.EOF) && line != "") {
+		return "", 0, err
+	}
+	size := len(line)
+	line = strings.TrimSuffix(line, "\n")
+	line = strings.TrimSuffix(line, "\r")
+	return line, size, nil
+}
+
+type lineWriter struct {
+	w *bufio.Writer
+}
+
+func (lw *lineWriter) Write(line string) error {
+	lw.w.WriteString(line)
+	return lw.w.WriteByte('\n')
+}
+
+func (lw *lineWriter) Close() error {
+	return lw.w.Flush()
+}
+
+// LengthPrefixedFormat stores binary records, each preceded by its length as a 4-byte big-endian integer.
+type LengthPrefixedFormat struct{}
+
+// NewReader returns a reader for length-prefixed binary records.
+func (LengthPrefixedFormat) NewReader(r io.Reader) RecordReader[[]byte] {
+	return &lengthPrefixedReader{r: bufio.NewReader(r)}
+}
+
+// NewWriter returns a writer for length-prefixed binary records.
+func (LengthPrefixedFormat) NewWriter(w io.Writer) RecordWriter[[]byte] {
+	return &lengthPrefixedWriter{w: bufio.NewWriter(w)}
+}
+
+type lengthPrefixedReader struct {
+	r *bufio.Reader
+}
+
+func (lr *lengthPrefixedReader) Read() ([]byte, int, error) {
+	var header [4]byte
+	if _, err := io.ReadFull(lr.r, header[:]); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, 0, fmt.Errorf("truncated record header: %w", err)
+		}
+		return nil, 0, err
+	}
+
+	record := make([]byte, binary.BigEndian.Uint32(header[:]))
+	if _, err := io.ReadFull(lr.r, record); err != nil {
+		return nil, 0, fmt.Errorf("truncated record: %w", io.ErrUnexpectedEOF)
+	}
+	return record, len(header) + len(record), nil
+}
+
+type lengthPrefixedWriter struct {
+	w *bufio.Writer
+}
+
+func (lw *lengthPrefixedWriter) Write(record []byte) error {
+	var header [4]byte
+	binary.BigEndian.PutUint32(header[:], uint32(len(record)))
+	lw.w.Write(header[:])
+	_, err := lw.w.Write(record)
+	return err
+}
+
+func (lw *lengthPrefixedWriter) Close() error {
+	return lw.w.Flush()
+}
+
+// JSONLinesFormat stores one JSON value per line (NDJSON), decoded into T.
+// Decoding each record once keeps compare cheap, e.g. comparing accounts by their Balance field.
+type JSONLinesFormat[T any] struct{}
+
+// NewReader returns a reader that decodes one JSON value per record.
+func (JSONLinesFormat[T]) NewReader(r io.Reader) RecordReader[T] {
+	return &jsonReader[T]{dec: json.NewDecoder(r)}
+}
+
+// NewWriter returns a writer that encodes every record on its own line.
+func (JSONLinesFormat[T]) NewWriter(w io.Writer) RecordWriter[T] {
+	bw := bufio.NewWriter(w)
+	return &jsonLinesWriter[T]{w: bw, enc: json.NewEncoder(bw)}
+}
+
+// JSONArrayFormat stores records as the elements of a single JSON array,
+// like 57_practice/list.json. The array is streamed, never loaded as a whole.
+type JSONArrayFormat[T any] struct{}
+
+// NewReader returns a reader that decodes the elements of a JSON array one by one.
+func (JSONArrayFormat[T]) NewReader(r io.Reader) RecordReader[T] {
+	return &jsonReader[T]{dec: json.NewDecoder(r), array: true}
+}
+
+// NewWriter returns a writer that wraps the records in [ ... ], one element per line.
+func (JSONArrayFormat[T]) NewWriter(w io.Writer) RecordWriter[T] {
+	return &jsonArrayWriter[T]{w: bufio.NewWriter(w)}
+}
+
+type jsonReader[T any] struct {
+	dec     *json.Decoder
+	array   bool // true when the records are wrapped in [ ... ]
+	started bool // true once the opening [ has been consumed
+}
+
+func (jr *jsonReader[T]) Read() (record T, size int, err error) {
+	if jr.array && !jr.started {
+		tok, err := jr.dec.Token()
+		if err != nil {
+			return record, 0, err
+		}
+		if d, ok := tok.(json.Delim); !ok || d != '[' {
+			return record, 0, fmt.Errorf("expected a JSON array, got %v", tok)
+		}
+		jr.started = true
+	}
+	if jr.array && !jr.dec.More() {
+		return record, 0, io.EOF
+	}
+
+	start := jr.dec.InputOffset()
+	if err := jr.dec.Decode(&record); err != nil {
+		return record, 0, err
+	}
+	return record, int(jr.dec.InputOffset() - start), nil
+}
+
+type jsonLinesWriter[T any] struct {
+	w   *bufio.Writer
+	enc *json.Encoder
+}
+
+func (jw *jsonLinesWriter[T]) Write(record T) error {
+	// Encode appends the newline itself
+	return jw.enc.Encode(record)
+}
+
+func (jw *jsonLinesWriter[T]) Close() error {
+	return jw.w.Flush()
+}
+
+type jsonArrayWriter[T any] struct {
+	w     *bufio.Writer
+	count int
+}
+
+func (jw *jsonArrayWriter[T]) Write(record T) error {
+	b, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	if jw.count == 0 {
+		jw.w.WriteString("[\n")
+	} else {
+		jw.w.WriteString(",\n")
+	}
+	jw.count++
+	_, err = jw.w.Write(b)
+	return err
+}
+
+func (jw *jsonArrayWriter[T]) Close() error {
+	if jw.count == 0 {
+		jw.w.WriteString("[]\n")
+	} else {
+		jw.w.WriteString("\n]\n")
+	}
+	return jw.w.Flush()
+}
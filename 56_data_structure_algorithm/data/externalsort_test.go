@@ -0,0 +1,231 @@
+package data
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// chunkCounter is the output of an ExternalSort under test. On the first write, which happens
+// while the chunks are being merged, it counts the chunk files in dir.
+type chunkCounter struct {
+	bytes.Buffer
+	t      *testing.T
+	dir    string
+	chunks int
+	seen   bool
+}
+
+func (cc *chunkCounter) Write(p []byte) (int, error) {
+	if !cc.seen {
+		cc.seen = true
+		cc.chunks = countFiles(cc.t, cc.dir)
+	}
+	return cc.Buffer.Write(p)
+}
+
+func countFiles(t *testing.T, dir string) int {
+	t.Helper()
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return len(files)
+}
+
+// sortLines runs ExternalSort on lines with the given budget and returns the output lines
+// and how many chunk files were spilled
+func sortLines(t *testing.T, lines []string, budget int64) ([]string, int) {
+	t.Helper()
+	dir := t.TempDir()
+	out := &chunkCounter{t: t, dir: dir}
+	in := strings.NewReader(strings.Join(lines, "\n") + "\n")
+	err := ExternalSort(in, out, LineFormat{}, strings.Compare, ExternalSortOptions{MemoryBudget: budget, TempDir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := countFiles(t, dir); n != 0 {
+		t.Fatalf("%d chunk files left behind", n)
+	}
+	return strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n"), out.chunks
+}
+
+func TestExternalSortLines(t *testing.T) {
+	r := newRand()
+	lines := make([]string, 5000)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("line-%06d", r.IntN(100_000))
+	}
+
+	tests := []struct {
+		name   string
+		lines  int // How many of lines to sort
+		budget int64
+		chunks int // Chunk files expected during the merge
+	}{
+		{"in memory", 5000, 1 << 20, 0},
+		// Every line is 12 bytes with its newline: 1200 bytes hold 100 lines
+		{"spilled", 5000, 1200, 50},
+		// Fewer lines: every chunk stays open during the merge
+		{"one record per chunk", 500, 1, 500},
+		{"default budget", 5000, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := slices.Sorted(slices.Values(lines[:tt.lines]))
+			got, chunks := sortLines(t, lines[:tt.lines], tt.budget)
+			if !slices.Equal(got, want) {
+				t.Fatalf("not sorted:\n got %q\nwant %q", got[:5], want[:5])
+			}
+			if chunks != tt.chunks {
+				t.Fatalf("%d chunk files during the merge, want %d", chunks, tt.chunks)
+			}
+		})
+	}
+}
+
+func TestExternalSortLengthPrefixed(t *testing.T) {
+	r := newRand()
+	var in bytes.Buffer
+	var records [][]byte
+	for range 3000 {
+		// Binary records of any length, including empty ones and ones containing '\n'
+		rec := make([]byte, r.IntN(20))
+		for i := range rec {
+			rec[i] = byte(r.IntN(256))
+		}
+		records = append(records, rec)
+		in.Write(binary.BigEndian.AppendUint32(nil, uint32(len(rec))))
+		in.Write(rec)
+	}
+
+	dir := t.TempDir()
+	out := &chunkCounter{t: t, dir: dir}
+	err := ExternalSort(&in, out, LengthPrefixedFormat{}, bytes.Compare, ExternalSortOptions{MemoryBudget: 2000, TempDir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.chunks < 2 {
+		t.Fatalf("%d chunk files: the budget did not force a spill", out.chunks)
+	}
+	if n := countFiles(t, dir); n != 0 {
+		t.Fatalf("%d chunk files left behind", n)
+	}
+
+	reader := LengthPrefixedFormat{}.NewReader(&out.Buffer)
+	var got [][]byte
+	for {
+		rec, _, err := reader.Read()
+		if err != nil {
+			break
+		}
+		got = append(got, rec)
+	}
+	slices.SortFunc(records, bytes.Compare)
+	if !slices.EqualFunc(got, records, bytes.Equal) {
+		t.Fatalf("got %d records, not the %d sorted input records", len(got), len(records))
+	}
+}
+
+// account is the record of the JSON tests; Seq is its position in the input
+type account struct {
+	ID      string `json:"id"`
+	Balance int    `json:"balance"`
+	Seq     int    `json:"seq"`
+}
+
+func TestExternalSortJSONStable(t *testing.T) {
+	r := newRand()
+	accounts := make([]account, 2000)
+	for i := range accounts {
+		// Few distinct balances, so the merge has to break many ties
+		accounts[i] = account{ID: fmt.Sprint("acc", i), Balance: r.IntN(10), Seq: i}
+	}
+	byBalance := func(a, b account) int { return cmp.Compare(a.Balance, b.Balance) }
+	want := slices.Clone(accounts)
+	slices.SortStableFunc(want, byBalance)
+
+	formats := []struct {
+		name   string
+		format RecordFormat[account]
+		encode func(*bytes.Buffer, []account)
+	}{
+		{"lines", JSONLinesFormat[account]{}, func(b *bytes.Buffer, as []account) {
+			enc := json.NewEncoder(b)
+			for _, a := range as {
+				enc.Encode(a)
+			}
+		}},
+		{"array", JSONArrayFormat[account]{}, func(b *bytes.Buffer, as []account) {
+			json.NewEncoder(b).Encode(as)
+		}},
+	}
+	for _, f := range formats {
+		t.Run(f.name, func(t *testing.T) {
+			var in bytes.Buffer
+			f.encode(&in, accounts)
+			dir := t.TempDir()
+			out := &chunkCounter{t: t, dir: dir}
+			if err := ExternalSort(&in, out, f.format, byBalance, ExternalSortOptions{MemoryBudget: 4000, TempDir: dir}); err != nil {
+				t.Fatal(err)
+			}
+			if out.chunks < 2 {
+				t.Fatalf("%d chunk files: the budget did not force a spill", out.chunks)
+			}
+
+			var got []account
+			reader := f.format.NewReader(&out.Buffer)
+			for {
+				a, _, err := reader.Read()
+				if err != nil {
+					break
+				}
+				got = append(got, a)
+			}
+			if !slices.Equal(got, want) {
+				t.Fatalf("not a stable sort by balance: got %d records, first %v", len(got), got[:min(5, len(got))])
+			}
+		})
+	}
+}
+
+// TestExternalSortError feeds a truncated record after several chunks have been spilled:
+// the error is returned and the chunk files are still removed
+func TestExternalSortError(t *testing.T) {
+	var in bytes.Buffer
+	for i := range 100 {
+		rec := fmt.Appendf(nil, "%03d", 100-i)
+		in.Write(binary.BigEndian.AppendUint32(nil, uint32(len(rec))))
+		in.Write(rec)
+	}
+	in.Write([]byte{0, 0, 0, 9, 'x'})
+
+	dir := t.TempDir()
+	var out bytes.Buffer
+	err := ExternalSort(&in, &out, LengthPrefixedFormat{}, bytes.Compare, ExternalSortOptions{MemoryBudget: 70, TempDir: dir})
+	if err == nil || !strings.Contains(err.Error(), "truncated record") {
+		t.Fatalf("ExternalSort error = %v, want a truncated record", err)
+	}
+	if n := countFiles(t, dir); n != 0 {
+		t.Fatalf("%d chunk files left behind after the error", n)
+	}
+	if out.Len() != 0 {
+		t.Fatalf("%d bytes written despite the error", out.Len())
+	}
+}
+
+func TestExternalSortEmpty(t *testing.T) {
+	for _, budget := range []int64{1, 0} {
+		var out bytes.Buffer
+		err := ExternalSort(strings.NewReader(""), &out, LineFormat{}, strings.Compare, ExternalSortOptions{MemoryBudget: budget, TempDir: t.TempDir()})
+		if err != nil || out.Len() != 0 {
+			t.Fatalf("sorting nothing = %q, %v", out.String(), err)
+		}
+	}
+}
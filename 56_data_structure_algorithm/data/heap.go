@@ -0,0 +1,165 @@
+package data
+
+import "cmp"
+
+// Heap is a generic d-ary min-heap: the smallest element (according to compare) is always on top.
+//
+// A binary heap (d = 2) stores a complete tree in a slice:
+// the children of items[i] are items[2i+1] and items[2i+2], its parent is items[(i-1)/2].
+// A d-ary heap gives every node d children instead, which makes the tree shallower.
+// That speeds up Push and decrease-key (fewer levels to sift up) at the cost of
+// slightly slower Pop (d children to compare on the way down).
+//
+// Time Complexity: Push O(log n), Pop O(d·log n / log d), Peek O(1)
+// Space Complexity: O(n)
+//
+// ✅ Use a max-heap by reversing compare, e.g. func(a, b int) int { return cmp.Compare(b, a) }.
+// (Not b - a: the subtraction overflows when a and b are large with opposite signs.)
+type Heap[T any] struct {
+	items   []T
+	arity   int
+	compare func(a, b T) int
+
+	// moved is called every time an item lands on a new index.
+	// PriorityQueue uses it to keep its handles up to date.
+	moved func(item T, index int)
+}
+
+// NewHeap creates an empty binary min-heap for any ordered element type.
+func NewHeap[T cmp.Ordered]() *Heap[T] {
+	return NewHeapFunc(cmp.Compare[T])
+}
+
+// NewHeapFunc creates an empty binary heap ordered by compare.
+//
+// 🔹 compare: returns a negative number when a < b, zero when a == b and a positive number when a > b
+func NewHeapFunc[T any](compare func(a, b T) int) *Heap[T] {
+	return NewDaryHeapFunc(2, compare)
+}
+
+// NewDaryHeapFunc creates an empty d-ary heap ordered by compare.
+//
+// 🔹 d: number of children per node (values below 2 are treated as 2)
+// 🔹 compare: returns a negative number when a < b, zero when a == b and a positive number when a > b
+func NewDaryHeapFunc[T any](d int, compare func(a, b T) int) *Heap[T] {
+	return &Heap[T]{arity: max(d, 2), compare: compare}
+}
+
+// Len returns the number of elements in the heap.
+func (h *Heap[T]) Len() int {
+	return len(h.items)
+}
+
+// Push adds item to the heap.
+func (h *Heap[T]) Push(item T) {
+	h.items = append(h.items, item)
+	h.notify(len(h.items) - 1)
+	h.up(len(h.items) - 1)
+}
+
+// Pop removes and returns the smallest element.
+// ok is false if the heap is empty.
+func (h *Heap[T]) Pop() (item T, ok bool) {
+	if len(h.items) == 0 {
+		return item, false
+	}
+	return h.removeAt(0), true
+}
+
+// Peek returns the smallest element without removing it.
+// ok is false if the heap is empty.
+func (h *Heap[T]) Peek() (item T, ok bool) {
+	if len(h.items) == 0 {
+		return item, false
+	}
+	return h.items[0], true
+}
+
+// heapify rearranges h.items into heap order in O(n).
+func (h *Heap[T]) heapify() {
+	n := len(h.items)
+	for i := (n - 2) / h.arity; i >= 0; i-- {
+		h.down(i)
+	}
+}
+
+// removeAt removes and returns the element at index i.
+func (h *Heap[T]) removeAt(i int) T {
+	last := len(h.items) - 1
+	item := h.items[i]
+
+	// Move the last element into the hole and restore heap order
+	if i != last {
+		h.swap(i, last)
+	}
+	var zero T
+	h.items[last] = zero // let the garbage collector reclaim removed pointers
+	h.items = h.items[:last]
+
+	if i != last {
+		h.fix(i)
+	}
+	return item
+}
+
+// fix restores heap order after the element at index i changed.
+func (h *Heap[T]) fix(i int) {
+	if !h.down(i) {
+		h.up(i)
+	}
+}
+
+// up moves the element at index i towards the root while it is smaller than its parent.
+func (h *Heap[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / h.arity
+		if h.compare(h.items[i], h.items[parent]) >= 0 {
+			return
+		}
+		h.swap(i, parent)
+		i = parent
+	}
+}
+
+// down moves the element at index i towards the leaves while a child is smaller.
+// It reports whether the element moved.
+func (h *Heap[T]) down(i int) bool {
+	start := i
+	n := len(h.items)
+
+	for {
+		// Find the smallest of the (up to d) children
+		first := h.arity*i + 1
+		if first >= n {
+			break
+		}
+		smallest := first
+		for c := first + 1; c < first+h.arity && c < n; c++ {
+			if h.compare(h.items[c], h.items[smallest]) < 0 {
+				smallest = c
+			}
+		}
+
+		if h.compare(h.items[smallest], h.items[i]) >= 0 {
+			break
+		}
+		h.swap(i, smallest)
+		i = smallest
+	}
+
+	return i != start
+}
+
+// swap exchanges two elements and reports their new positions.
+func (h *Heap[T]) swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.notify(i)
+	h.notify(j)
+}
+
+// notify reports the position of items[i] to the moved callback, if any.
+func (h *Heap[T]) notify(i int) {
+	if h.moved != nil {
+		h.moved(h.items[i], i)
+	}
+}
@@ -0,0 +1,179 @@
+package data
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"testing"
+)
+
+// arities are the heap shapes the tests run with
+var arities = []int{2, 3, 4, 8}
+
+// checkHeap fails the test if some element of h is smaller than its parent
+func checkHeap[T any](t *testing.T, h *Heap[T]) {
+	t.Helper()
+	for i := 1; i < len(h.items); i++ {
+		parent := (i - 1) / h.arity
+		if h.compare(h.items[parent], h.items[i]) > 0 {
+			t.Fatalf("heap order broken: items[%d] = %v is smaller than its parent items[%d] = %v",
+				i, h.items[i], parent, h.items[parent])
+		}
+	}
+}
+
+func TestHeap(t *testing.T) {
+	for _, d := range arities {
+		for _, kind := range inputKinds {
+			t.Run(fmt.Sprintf("d=%d/%s", d, kind.name), func(t *testing.T) {
+				input := kind.gen(newRand(), 1000)
+				h := NewDaryHeapFunc(d, cmp.Compare[int])
+				for _, v := range input {
+					h.Push(v)
+				}
+				checkHeap(t, h)
+				if h.Len() != len(input) {
+					t.Fatalf("Len = %d, want %d", h.Len(), len(input))
+				}
+
+				got := make([]int, 0, len(input))
+				for h.Len() > 0 {
+					top, _ := h.Peek()
+					v, ok := h.Pop()
+					if !ok || v != top {
+						t.Fatalf("Pop = %d, %v after Peek = %d", v, ok, top)
+					}
+					got = append(got, v)
+				}
+				checkSorted(t, input, got)
+
+				if _, ok := h.Pop(); ok {
+					t.Fatal("Pop on an empty heap reported ok")
+				}
+				if _, ok := h.Peek(); ok {
+					t.Fatal("Peek on an empty heap reported ok")
+				}
+			})
+		}
+	}
+}
+
+// TestHeapMax uses the max-heap comparator from the Heap documentation
+// on values whose difference does not fit in an int
+func TestHeapMax(t *testing.T) {
+	h := NewHeapFunc(func(a, b int) int { return cmp.Compare(b, a) })
+	input := []int{math.MinInt, 0, math.MaxInt, -1, 1, math.MinInt + 1, math.MaxInt - 1}
+	for _, v := range input {
+		h.Push(v)
+	}
+	checkHeap(t, h)
+
+	var got []int
+	for v, ok := h.Pop(); ok; v, ok = h.Pop() {
+		got = append(got, v)
+	}
+	want := slices.Clone(input)
+	slices.Sort(want)
+	slices.Reverse(want)
+	if !slices.Equal(got, want) {
+		t.Fatalf("max-heap popped %v, want %v", got, want)
+	}
+}
+
+// checkQueue fails the test if the heap of pq is out of order or a live handle
+// does not point at its own slot
+func checkQueue[V any, P any](t *testing.T, pq *PriorityQueue[V, P], live map[*PQItem[V, P]]bool) {
+	t.Helper()
+	checkHeap(t, pq.heap)
+	if pq.Len() != len(live) {
+		t.Fatalf("Len = %d, want %d", pq.Len(), len(live))
+	}
+	for it := range live {
+		if it.index < 0 || it.index >= pq.Len() || pq.heap.items[it.index] != it {
+			t.Fatalf("handle of %v has index %d, which holds another item", it.Value, it.index)
+		}
+	}
+}
+
+func TestPriorityQueueUpdateRemove(t *testing.T) {
+	for _, d := range arities {
+		t.Run(fmt.Sprintf("d=%d", d), func(t *testing.T) {
+			r := newRand()
+			pq := NewDaryPriorityQueueFunc[int](d, cmp.Compare[int])
+			live := make(map[*PQItem[int, int]]bool)
+			var handles []*PQItem[int, int] // Live and removed ones
+			for i := range 500 {
+				handles = append(handles, pq.Push(i, r.IntN(1000)))
+				live[handles[i]] = true
+			}
+			checkQueue(t, pq, live)
+
+			// Random decrease-key, increase-key and removals, checking the heap after each one
+			for range 2000 {
+				it := handles[r.IntN(len(handles))]
+				if r.IntN(4) == 0 {
+					if ok := pq.Remove(it); ok != live[it] {
+						t.Fatalf("Remove(%v) = %v, want %v", it.Value, ok, live[it])
+					}
+					delete(live, it)
+				} else {
+					p := r.IntN(1000)
+					if ok := pq.Update(it, p); ok != live[it] {
+						t.Fatalf("Update(%v) = %v, want %v", it.Value, ok, live[it])
+					}
+					if live[it] && it.Priority() != p {
+						t.Fatalf("priority of %v is %d after Update to %d", it.Value, it.Priority(), p)
+					}
+				}
+				checkQueue(t, pq, live)
+			}
+
+			// The remaining values come out in priority order, and their handles go stale
+			var want []int
+			for it := range live {
+				want = append(want, it.Priority())
+			}
+			slices.Sort(want)
+			for i, p := range want {
+				v, got, ok := pq.Pop()
+				if !ok || got != p {
+					t.Fatalf("Pop %d = priority %d, %v; want %d", i, got, ok, p)
+				}
+				it := handles[v]
+				if pq.Update(it, 0) || pq.Remove(it) {
+					t.Fatalf("the handle of popped value %d still works", v)
+				}
+			}
+			if pq.Len() != 0 {
+				t.Fatalf("%d values left", pq.Len())
+			}
+		})
+	}
+}
+
+func TestPriorityQueueForeignHandle(t *testing.T) {
+	a := NewPriorityQueue[string, int]()
+	b := NewPriorityQueue[string, int]()
+	a.Push("x", 1)
+	it := b.Push("y", 2)
+	if a.Update(it, 0) || a.Remove(it) || a.Update(nil, 0) {
+		t.Fatal("a queue accepted a handle it does not hold")
+	}
+	if v, p, _ := b.Peek(); v != "y" || p != 2 {
+		t.Fatalf("b.Peek = %s, %d; want y, 2", v, p)
+	}
+}
+
+func TestHeapsort(t *testing.T) {
+	for _, kind := range inputKinds {
+		for _, n := range testSizes {
+			t.Run(fmt.Sprintf("%s/%d", kind.name, n), func(t *testing.T) {
+				input := kind.gen(newRand(), n)
+				got := slices.Clone(input)
+				Heapsort(got)
+				checkSorted(t, input, got)
+			})
+		}
+	}
+}
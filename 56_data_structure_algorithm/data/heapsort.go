@@ -0,0 +1,47 @@
+package data
+
+import "cmp"
+
+// Heapsort sorts a slice of integers in ascending order using the Heap Sort algorithm.
+//
+// 🔹 items: slice of integers to be sorted
+//
+// Heap Sort reuses the slice itself as the storage of a Heap:
+// 1. Arrange the slice into a max-heap, so the largest element is at index 0.
+// 2. Swap it with the last element and shrink the heap by one.
+// 3. Sift the new root down to restore the heap, and repeat.
+//
+// Time Complexity: O(n log n) for all cases (best, average, worst)
+// Space Complexity: O(1) (in-place sorting)
+//
+// ⚠️ Not stable. Slower than Quicksort on average, but it never degrades,
+// which is why Quicksort falls back to it on bad inputs.
+func Heapsort(items []int) {
+	HeapsortOrdered(items)
+}
+
+// HeapsortOrdered is the generic form of Heapsort for any ordered element type.
+func HeapsortOrdered[T cmp.Ordered](items []T) {
+	HeapsortFunc(items, cmp.Compare[T])
+}
+
+// HeapsortFunc sorts items in ascending order as determined by the compare function.
+//
+// 🔹 items: slice of any type to be sorted
+// 🔹 compare: returns a negative number when a < b, zero when a == b and a positive number when a > b
+func HeapsortFunc[T any](items []T, compare func(a, b T) int) {
+	// Reverse the comparison to turn the min-heap into a max-heap
+	h := &Heap[T]{
+		items:   items,
+		arity:   2,
+		compare: func(a, b T) int { return compare(b, a) },
+	}
+	h.heapify()
+
+	// Repeatedly move the largest element just past the end of the shrinking heap
+	for end := len(items) - 1; end > 0; end-- {
+		items[0], items[end] = items[end], items[0]
+		h.items = items[:end]
+		h.down(0)
+	}
+}
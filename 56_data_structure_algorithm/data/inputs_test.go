@@ -0,0 +1,98 @@
+package data
+
+import (
+	"math/rand/v2"
+	"slices"
+)
+
+// inputKind is a shape of input data that the tests and benchmarks sort and search
+type inputKind struct {
+	name string
+	gen  func(r *rand.Rand, n int) []int
+}
+
+// inputKinds covers the shapes that break naive sorts: already sorted data,
+// reversed data, long runs of duplicates and patterns that fool pivot choices
+var inputKinds = []inputKind{
+	{"random", func(r *rand.Rand, n int) []int {
+		a := make([]int, n)
+		for i := range a {
+			a[i] = r.IntN(1 << 30)
+		}
+		return a
+	}},
+	{"sorted", func(r *rand.Rand, n int) []int {
+		a := make([]int, n)
+		for i := range a {
+			a[i] = i
+		}
+		return a
+	}},
+	{"reversed", func(r *rand.Rand, n int) []int {
+		a := make([]int, n)
+		for i := range a {
+			a[i] = n - i
+		}
+		return a
+	}},
+	{"nearly-sorted", func(r *rand.Rand, n int) []int {
+		a := make([]int, n)
+		for i := range a {
+			a[i] = i
+		}
+		// Swap about 1% of the elements out of place
+		for range n / 100 {
+			i, j := r.IntN(n), r.IntN(n)
+			a[i], a[j] = a[j], a[i]
+		}
+		return a
+	}},
+	{"few-unique", func(r *rand.Rand, n int) []int {
+		a := make([]int, n)
+		for i := range a {
+			a[i] = r.IntN(8)
+		}
+		return a
+	}},
+	{"organ-pipe", func(r *rand.Rand, n int) []int {
+		// Ascending then descending: the classic median-of-three killer
+		a := make([]int, n)
+		for i := range a {
+			a[i] = min(i, n-i)
+		}
+		return a
+	}},
+	{"sawtooth", func(r *rand.Rand, n int) []int {
+		a := make([]int, n)
+		for i := range a {
+			a[i] = i % 64
+		}
+		return a
+	}},
+}
+
+// newRand returns a generator with a fixed seed, so failures can be reproduced
+func newRand() *rand.Rand {
+	return rand.New(rand.NewPCG(1, 2))
+}
+
+// testSizes includes the sizes around the insertion sort and run length cutoffs
+var testSizes = []int{0, 1, 2, 3, 15, 16, 17, 31, 32, 33, 63, 64, 65, 100, 1000, 10_000}
+
+// checkSorted fails the test if got is not what slices.Sort makes of input
+func checkSorted(t interface {
+	Helper()
+	Fatalf(format string, args ...any)
+}, input, got []int) {
+	t.Helper()
+	want := slices.Clone(input)
+	slices.Sort(want)
+	if !slices.Equal(got, want) {
+		t.Fatalf("not sorted:\ninput %v\ngot   %v\nwant  %v", head(input), head(got), head(want))
+	}
+}
+
+// head keeps failure messages short for large inputs
+func head(a []int) []int {
+	return a[:min(len(a), 20)]
+}
@@ -1,5 +1,7 @@
 package data
 
+import "cmp"
+
 // Insertionsort sorts a slice of integers in ascending order using the Insertion Sort algorithm.
 //
 // 🔹 items: slice of integers to be sorted
@@ -11,6 +13,21 @@ package data
 // Space Complexity: O(1) (in-place sorting)
 // ✅ Efficient for small datasets or nearly sorted arrays. Not recommended for large unsorted datasets.
 func Insertionsort(items []int) {
+	InsertionsortOrdered(items)
+}
+
+// InsertionsortOrdered is the generic form of Insertionsort for any ordered element type.
+func InsertionsortOrdered[T cmp.Ordered](items []T) {
+	InsertionsortFunc(items, cmp.Compare[T])
+}
+
+// InsertionsortFunc sorts items in ascending order as determined by the compare function.
+//
+// 🔹 items: slice of any type to be sorted
+// 🔹 compare: returns a negative number when a < b, zero when a == b and a positive number when a > b
+//
+// Equal elements are never swapped, so the sort is stable.
+func InsertionsortFunc[T any](items []T, compare func(a, b T) int) {
 	n := len(items)
 
 	// Iterate over the array starting from the second element
@@ -18,7 +35,7 @@ func Insertionsort(items []int) {
 		j := i
 
 		// Move the current element backward until it is in the correct position
-		for j > 0 && items[j-1] > items[j] {
+		for j > 0 && compare(items[j-1], items[j]) > 0 {
 			// Swap elements if they are out of order
 			items[j-1], items[j] = items[j], items[j-1]
 			j = j - 1
@@ -0,0 +1,198 @@
+package data
+
+// OpKind is the kind of operation a sorting algorithm performs on its slice.
+type OpKind int
+
+const (
+	OpCompare OpKind = iota // Two elements were compared
+	OpSwap                  // Two elements traded places
+	OpWrite                 // One position received a new element
+)
+
+// String returns the lower-case name of the operation, e.g. "swap".
+func (k OpKind) String() string {
+	switch k {
+	case OpCompare:
+		return "compare"
+	case OpSwap:
+		return "swap"
+	case OpWrite:
+		return "write"
+	}
+	return "unknown"
+}
+
+// MarshalText makes OpKind appear as its name in JSON.
+func (k OpKind) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+// Event is one operation reported by Instrument.
+//
+// 🔹 Compare: I and J are the positions of the two elements compared
+// 🔹 Swap: I and J are the positions that traded elements
+// 🔹 Write: I is the position written, J is -1
+type Event struct {
+	Kind OpKind `json:"kind"`
+	I    int    `json:"i"`
+	J    int    `json:"j"`
+}
+
+// OpCounts is the number of operations of each kind performed during a sort.
+type OpCounts struct {
+	Compares int `json:"compares"`
+	Swaps    int `json:"swaps"`
+	Writes   int `json:"writes"`
+}
+
+// Observer receives every event of an instrumented sort,
+// together with the state of the slice right after the event.
+// The items slice is only valid during the call; copy it to keep it.
+type Observer[T any] interface {
+	Observe(e Event, items []T)
+}
+
+// ObserverFunc adapts an ordinary function to the Observer interface.
+type ObserverFunc[T any] func(e Event, items []T)
+
+// Observe calls f(e, items).
+func (f ObserverFunc[T]) Observe(e Event, items []T) {
+	f(e, items)
+}
+
+// Instrument runs sort on items and reports every compare, swap and write to obs (which may be nil).
+// It returns how many operations of each kind were seen.
+//
+// 🔹 items: slice to be sorted (it is sorted in place, like calling sort directly)
+// 🔹 compare: returns a negative number when a < b, zero when a == b and a positive number when a > b
+// 🔹 sort: the int form of any ...Func sort of this package, e.g. data.BubblesortFunc[int]
+// 🔹 obs: receives each event and the slice state after it
+//
+// No algorithm needs to be changed for this. Instead of the elements themselves, sort
+// rearranges their ids (0..n-1) and compares two ids by comparing the elements they stand for.
+// Whenever it calls compare, the ids are diffed against the last known state:
+// positions that exchanged ids are reported as swaps, any other changed positions as writes.
+// Then the comparison itself is reported.
+// Writes that are overwritten again before the next comparison are therefore not counted.
+//
+// ⚠️ Parallel sorts cannot be instrumented: they compare from several goroutines at once.
+func Instrument[T any](items []T, compare func(a, b T) int, sort func(ids []int, compare func(a, b int) int), obs Observer[T]) OpCounts {
+	n := len(items)
+	values := append([]T(nil), items...) // the element behind every id
+	work := make([]int, n)               // the ids, as rearranged by the algorithm
+	seen := make([]int, n)               // the ids as last reported to the observer
+	view := make([]T, n)                 // the elements behind seen, handed to the observer
+	pos := make([]int, n)                // last known position of every id
+
+	for i := range work {
+		work[i], seen[i], pos[i] = i, i, i
+	}
+	copy(view, items)
+
+	var counts OpCounts
+	emit := func(e Event) {
+		switch e.Kind {
+		case OpCompare:
+			counts.Compares++
+		case OpSwap:
+			counts.Swaps++
+		case OpWrite:
+			counts.Writes++
+		}
+		if obs != nil {
+			obs.Observe(e, view)
+		}
+	}
+
+	// sync reports how work changed since the last call
+	sync := func() {
+		var changed []int
+		for i := range work {
+			if work[i] != seen[i] {
+				changed = append(changed, i)
+			}
+		}
+
+		// Pair up positions that traded elements
+		for a, i := range changed {
+			if work[i] == seen[i] {
+				continue
+			}
+			for _, j := range changed[a+1:] {
+				if work[i] == seen[j] && work[j] == seen[i] {
+					seen[i], seen[j] = seen[j], seen[i]
+					view[i], view[j] = view[j], view[i]
+					pos[seen[i]], pos[seen[j]] = i, j
+					emit(Event{Kind: OpSwap, I: i, J: j})
+					break
+				}
+			}
+		}
+
+		// Everything else is a plain write
+		for _, i := range changed {
+			if work[i] != seen[i] {
+				seen[i] = work[i]
+				view[i] = values[work[i]]
+				pos[work[i]] = i
+				emit(Event{Kind: OpWrite, I: i, J: -1})
+			}
+		}
+	}
+
+	sort(work, func(a, b int) int {
+		sync()
+		emit(Event{Kind: OpCompare, I: pos[a], J: pos[b]})
+		return compare(values[a], values[b])
+	})
+	sync()
+
+	for i, id := range work {
+		items[i] = values[id]
+	}
+	return counts
+}
+
+// SortAlgorithm describes one of the sorts in this package, for tools that run them by name.
+type SortAlgorithm struct {
+	Name     string                                        // Short name, e.g. "quick"
+	Sort     func(items []int, compare func(a, b int) int) // The algorithm's ...Func form for ints
+	Stable   bool                                          // Equal elements keep their order
+	Parallel bool                                          // Uses several goroutines, cannot be instrumented
+
+	// NonComparison sorts ignore compare and order ints by their value directly,
+	// so they cannot be instrumented either.
+	NonComparison bool
+}
+
+// SortAlgorithms returns every sorting algorithm of the package, roughly from slowest to fastest.
+func SortAlgorithms() []SortAlgorithm {
+	return []SortAlgorithm{
+		{Name: "bubble", Sort: BubblesortFunc[int], Stable: true},
+		{Name: "selection", Sort: SelectionsortFunc[int]},
+		{Name: "insertion", Sort: InsertionsortFunc[int], Stable: true},
+		{Name: "comb", Sort: CombsortFunc[int]},
+		{Name: "heap", Sort: HeapsortFunc[int]},
+		{Name: "quick", Sort: func(items []int, compare func(a, b int) int) { QuicksortFunc(items, compare) }},
+		{Name: "merge", Sort: MergesortFunc[int], Stable: true},
+		{Name: "bottomup-merge", Sort: BottomUpMergesortFunc[int], Stable: true},
+		{Name: "tim", Sort: TimsortFunc[int], Stable: true},
+		{Name: "parallel-quick", Sort: ParallelQuicksortFunc[int], Parallel: true},
+		{Name: "parallel-merge", Sort: ParallelMergesortFunc[int], Stable: true, Parallel: true},
+		{Name: "radix", Sort: ignoreCompare(Radixsort), Stable: true, NonComparison: true},
+		{Name: "counting", Sort: ignoreCompare(Countingsort), Stable: true, NonComparison: true},
+		{Name: "bucket", Sort: ignoreCompare(bucketsortInts), Stable: true, NonComparison: true},
+	}
+}
+
+// ignoreCompare adapts a non-comparison int sort to the SortAlgorithm.Sort signature.
+func ignoreCompare(sort func([]int)) func([]int, func(a, b int) int) {
+	return func(items []int, _ func(a, b int) int) {
+		sort(items)
+	}
+}
+
+// bucketsortInts runs Bucketsort on ints, using their value as the float key.
+func bucketsortInts(items []int) {
+	BucketsortFunc(items, func(v int) float64 { return float64(v) })
+}
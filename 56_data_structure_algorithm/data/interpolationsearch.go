@@ -5,58 +5,85 @@ package data
 // 🔹 array: a sorted slice of integers (ascending order required)
 // 🔹 key: the value to search for
 //
-// Returns the index of the first occurrence of the key if found.
-// If the key is not found, it returns the index where the key would be inserted.
+// Returns the index of the first occurrence of the key and true if found.
+// If the key is not found, it returns the index where the key would be inserted and false,
+// the same result as LowerBound.
 //
 // Interpolation Search improves upon binary search by estimating the position
 // based on the value of the key relative to the first and last elements.
 // Best used when elements are uniformly distributed.
+// Empty slices, runs of duplicates and extreme values are all handled safely.
 //
 // Time Complexity:
 // - Best case: O(log log n) (uniform distribution)
-// - Worst case: O(n) (non-uniform distribution)
+// - Worst case: O(n) (non-uniform distribution, see InterpolationSearchAdaptive)
 // Space Complexity: O(1) (in-place)
-func InterpolationSearch(array []int, key int) int {
-	min, max := array[0], array[len(array)-1]
-	low, high := 0, len(array)-1
-
-	for {
-		// Key is smaller than minimum element, insert at beginning
-		if key < min {
-			return low
+func InterpolationSearch(array []int, key int) (int, bool) {
+	return interpolationSearch(array, key, false)
+}
+
+// InterpolationSearchAdaptive is InterpolationSearch with a safety net for skewed data.
+//
+// Whenever an interpolation guess fails to cut the search range at least in half,
+// the next probe is taken at the middle of the range, like BinarySearch.
+// At least every other step therefore halves the range.
+//
+// Time Complexity:
+// - Best case: O(log log n) (uniform distribution)
+// - Worst case: O(log n) (any distribution)
+func InterpolationSearchAdaptive(array []int, key int) (int, bool) {
+	return interpolationSearch(array, key, true)
+}
+
+func interpolationSearch(array []int, key int, adaptive bool) (int, bool) {
+	// The answer (first index with array[i] >= key) always lies in [low, high]
+	low, high := 0, len(array)
+	bisect := false
+
+	for low < high {
+		min, max := array[low], array[high-1]
+
+		// Key is smaller than or equal to the minimum element: it belongs at low
+		if key <= min {
+			break
 		}
 
 		// Key is larger than maximum element, insert at end
 		if key > max {
-			return high + 1
+			low = high
+			break
 		}
 
-		// Estimate the position of the key (interpolation formula)
+		// Here min < key <= max, so max-min is never zero
 		var guess int
-		if high == low {
-			guess = high
+		if bisect {
+			guess = int(uint(low+high) >> 1)
 		} else {
-			size := high - low
-			offset := int(float64(size-1) * (float64(key-min) / float64(max-min)))
+			// Estimate the position of the key (interpolation formula), in float64 to avoid overflow
+			size := high - 1 - low
+			offset := int(float64(size) * ((float64(key) - float64(min)) / (float64(max) - float64(min))))
 			guess = low + offset
 		}
 
-		// Check if the guessed position contains the key
-		if array[guess] == key {
-			// Scan backward to find the first occurrence in case of duplicates
-			for guess > 0 && array[guess-1] == key {
-				guess--
-			}
-			return guess
-		}
+		// Keep the guess inside the range even if rounding pushed it out
+		guess = clamp(guess, low, high-1)
 
 		// Adjust search range
-		if array[guess] > key {
-			high = guess - 1
-			max = array[high]
-		} else {
+		before := high - low
+		if array[guess] < key {
 			low = guess + 1
-			min = array[low]
+		} else {
+			high = guess
 		}
+
+		// Fall back to a binary step if the range did not shrink at least by half
+		bisect = adaptive && !bisect && (high-low)*2 > before
 	}
+
+	return low, low < len(array) && array[low] == key
+}
+
+// clamp limits v to the range [low, high].
+func clamp(v, low, high int) int {
+	return max(low, min(v, high))
 }
@@ -0,0 +1,54 @@
+package data
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+// skewedFromBytes turns fuzz input into a sorted slice whose shape depends on mode:
+// uniform, duplicate-heavy, exponentially skewed, or spread out to the ends of the int range
+func skewedFromBytes(b []byte, mode uint8) []int {
+	items := make([]int, len(b))
+	for i, v := range b {
+		switch mode % 4 {
+		case 0:
+			items[i] = int(v)
+		case 1:
+			items[i] = int(v % 4)
+		case 2:
+			items[i] = 1 << (v % 62)
+		case 3:
+			// Gaps of about 2^56 reaching close to math.MinInt and math.MaxInt
+			items[i] = int(int8(v)) * (math.MaxInt / 128)
+		}
+	}
+	slices.Sort(items)
+	return items
+}
+
+func FuzzInterpolationSearch(f *testing.F) {
+	f.Add([]byte{}, uint8(0), 0)
+	f.Add([]byte{7}, uint8(0), 7)
+	f.Add([]byte{3, 3, 3, 3, 3, 3}, uint8(0), 3)
+	f.Add([]byte{0, 1, 2, 3, 4, 5, 6, 7}, uint8(1), 2)
+	f.Add([]byte{0, 1, 2, 3, 60, 61}, uint8(2), 8)
+	f.Add([]byte{0, 1, 2, 3, 60, 61}, uint8(2), 1<<61)
+	f.Add([]byte{0, 255, 128}, uint8(3), math.MaxInt)
+	f.Add([]byte{0, 255, 128}, uint8(3), math.MinInt)
+
+	f.Fuzz(func(t *testing.T, b []byte, mode uint8, key int) {
+		items := skewedFromBytes(b, mode)
+
+		// Binary search is the reference: same index and found flag as LowerBound
+		want := LowerBound(items, key)
+		wantFound := BinarySearch(items, key)
+
+		if i, found := InterpolationSearch(items, key); i != want || found != wantFound {
+			t.Fatalf("InterpolationSearch(%v, %d) = %d, %v, want %d, %v", items, key, i, found, want, wantFound)
+		}
+		if i, found := InterpolationSearchAdaptive(items, key); i != want || found != wantFound {
+			t.Fatalf("InterpolationSearchAdaptive(%v, %d) = %d, %v, want %d, %v", items, key, i, found, want, wantFound)
+		}
+	})
+}
@@ -0,0 +1,177 @@
+package data
+
+import "sync"
+
+// LFUCache is a fixed-size cache that evicts the least frequently used entry when it is full.
+// Among entries used equally often, the least recently used one goes first.
+//
+// Entries are grouped by how many times they were used: freqs[f] is a List of the keys
+// used exactly f times, most recent at the front. minFreq remembers the smallest
+// non-empty group, so the entry to evict is always at the back of freqs[minFreq].
+// A use moves a key from freqs[f] to the front of freqs[f+1], which is O(1).
+//
+// Time Complexity: Get, Peek, Put, Delete O(1)
+// (the first eviction after a Delete may scan the distinct use counts once)
+// Space Complexity: O(capacity)
+//
+// ✅ Safe for concurrent use by multiple goroutines.
+// ⚠️ Entries that were popular long ago keep their high counts; for shifting workloads LRUCache may do better.
+type LFUCache[K comparable, V any] struct {
+	mu       sync.Mutex
+	capacity int
+	items    map[K]*lfuEntry[K, V]
+	freqs    map[int]*List[K] // Use count -> keys with that count
+	minFreq  int
+	onEvict  EvictionFunc[K, V]
+	stats    CacheStats
+}
+
+type lfuEntry[K comparable, V any] struct {
+	value V
+	freq  int      // Number of Get and Put calls for this key
+	node  *Node[K] // The key's node in freqs[freq]
+}
+
+// NewLFUCache creates an empty cache holding at most capacity entries (at least 1).
+//
+// 🔹 capacity: maximum number of entries
+// 🔹 onEvict: called for each entry evicted to make room; may be nil
+func NewLFUCache[K comparable, V any](capacity int, onEvict EvictionFunc[K, V]) *LFUCache[K, V] {
+	capacity = max(capacity, 1)
+	return &LFUCache[K, V]{
+		capacity: capacity,
+		items:    make(map[K]*lfuEntry[K, V], capacity),
+		freqs:    make(map[int]*List[K]),
+		onEvict:  onEvict,
+	}
+}
+
+// Get returns the value for key and counts the use; ok is false if it is not cached.
+func (c *LFUCache[K, V]) Get(key K) (value V, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.items[key]
+	if !ok {
+		c.stats.Misses++
+		return value, false
+	}
+	c.stats.Hits++
+	c.touch(key, e)
+	return e.value, true
+}
+
+// Peek returns the value for key without counting the use or changing the stats.
+func (c *LFUCache[K, V]) Peek(key K) (value V, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.items[key]; ok {
+		return e.value, true
+	}
+	return value, false
+}
+
+// Put stores value for key and counts the use.
+// If the cache is full, the least frequently used entry is evicted first.
+func (c *LFUCache[K, V]) Put(key K, value V) {
+	var out []evicted[K, V]
+
+	c.mu.Lock()
+	if e, ok := c.items[key]; ok {
+		e.value = value
+		c.touch(key, e)
+	} else {
+		if len(c.items) >= c.capacity {
+			out = append(out, c.evictLeastUsed())
+		}
+		c.items[key] = &lfuEntry[K, V]{value: value, freq: 1, node: c.bucket(1).PushFront(key)}
+		// A new key always has the lowest possible count
+		c.minFreq = 1
+	}
+	c.mu.Unlock()
+
+	notifyEvicted(c.onEvict, out)
+}
+
+// touch moves key from its current frequency list to the next one.
+func (c *LFUCache[K, V]) touch(key K, e *lfuEntry[K, V]) {
+	c.unlink(e)
+	e.freq++
+	e.node = c.bucket(e.freq).PushFront(key)
+}
+
+// unlink removes e's node from its frequency list, dropping the list once it is empty.
+func (c *LFUCache[K, V]) unlink(e *lfuEntry[K, V]) {
+	l := c.freqs[e.freq]
+	l.RemoveNode(e.node)
+	if l.Len() == 0 {
+		delete(c.freqs, e.freq)
+		// The entry is about to move to freq+1 (touch) or leave the cache (Delete, evict).
+		// In the second case minFreq may now point at a missing list, which evictLeastUsed handles.
+		if c.minFreq == e.freq {
+			c.minFreq++
+		}
+	}
+}
+
+// bucket returns the list for freq, creating it if needed.
+func (c *LFUCache[K, V]) bucket(freq int) *List[K] {
+	l, ok := c.freqs[freq]
+	if !ok {
+		l = &List[K]{}
+		c.freqs[freq] = l
+	}
+	return l
+}
+
+// evictLeastUsed removes the oldest key with the lowest count; the cache must not be empty.
+func (c *LFUCache[K, V]) evictLeastUsed() evicted[K, V] {
+	// minFreq is exact after Get and Put; after a Delete it can point at a missing list,
+	// so look for the smallest count that still has one (at most one list per distinct count)
+	if c.freqs[c.minFreq] == nil {
+		c.minFreq = 0
+		for f := range c.freqs {
+			if c.minFreq == 0 || f < c.minFreq {
+				c.minFreq = f
+			}
+		}
+	}
+	key := c.freqs[c.minFreq].Back().Value
+	e := c.items[key]
+	c.unlink(e)
+	delete(c.items, key)
+	c.stats.Evictions++
+	return evicted[K, V]{key: key, value: e.value, reason: EvictedCapacity}
+}
+
+// Delete removes key and reports whether it was cached. The EvictionFunc is not called.
+func (c *LFUCache[K, V]) Delete(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.items[key]
+	if !ok {
+		return false
+	}
+	c.unlink(e)
+	delete(c.items, key)
+	if len(c.items) == 0 {
+		c.minFreq = 0
+	}
+	return true
+}
+
+// Len returns the number of cached entries.
+func (c *LFUCache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.items)
+}
+
+// Stats returns a snapshot of the hit, miss and eviction counters.
+func (c *LFUCache[K, V]) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
@@ -1,69 +1,277 @@
 package data
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"strings"
+)
 
-// Node represents a node in a singly linked list
-type Node struct {
-	data int    // The value stored in the node
-	next *Node  // Pointer to the next node
+// ErrIndexOutOfRange is returned by List.InsertAt when the index is outside [0, Len()].
+var ErrIndexOutOfRange = errors.New("data: index out of range")
+
+// Node represents a node in a doubly linked list
+type Node[T any] struct {
+	Value T        // The value stored in the node
+	next  *Node[T] // Pointer to the next node
+	prev  *Node[T] // Pointer to the previous node
+	list  *List[T] // The list this node belongs to, nil once removed
+}
+
+// Next returns the following node, or nil at the end of the list
+func (n *Node[T]) Next() *Node[T] {
+	return n.next
+}
+
+// Prev returns the preceding node, or nil at the start of the list
+func (n *Node[T]) Prev() *Node[T] {
+	return n.prev
+}
+
+// List represents a doubly linked list.
+// The zero value is an empty list ready to use: var l data.List[int]
+//
+// Keeping pointers to both ends makes pushing and popping at either end O(1).
+// T can be any type; Find and Remove, which compare values with ==, need a comparable T.
+type List[T any] struct {
+	head *Node[T] // Pointer to the first node in the list
+	tail *Node[T] // Pointer to the last node in the list
+	len  int      // Number of nodes
+}
+
+// Len returns the number of values in the list
+func (l *List[T]) Len() int {
+	return l.len
+}
+
+// Front returns the first node, or nil if the list is empty
+func (l *List[T]) Front() *Node[T] {
+	return l.head
 }
 
-// List represents a singly linked list
-type List struct {
-	head *Node  // Pointer to the first node in the list
+// Back returns the last node, or nil if the list is empty
+func (l *List[T]) Back() *Node[T] {
+	return l.tail
 }
 
 // Add appends a new value to the end of the list
-func (l *List) Add(value int) {
-	newNode := &Node{data: value}
+func (l *List[T]) Add(value T) {
+	l.PushBack(value)
+}
+
+// PushBack appends a new value to the end of the list and returns its node
+func (l *List[T]) PushBack(value T) *Node[T] {
+	return l.insertAfter(value, l.tail)
+}
+
+// PushFront inserts a new value at the start of the list and returns its node
+func (l *List[T]) PushFront(value T) *Node[T] {
+	return l.insertAfter(value, nil)
+}
 
-	// If list is empty, new node becomes the head
+// PopFront removes and returns the first value; ok is false if the list is empty
+func (l *List[T]) PopFront() (value T, ok bool) {
 	if l.head == nil {
+		return value, false
+	}
+	return l.RemoveNode(l.head), true
+}
+
+// PopBack removes and returns the last value; ok is false if the list is empty
+func (l *List[T]) PopBack() (value T, ok bool) {
+	if l.tail == nil {
+		return value, false
+	}
+	return l.RemoveNode(l.tail), true
+}
+
+// InsertBefore inserts a new value right before mark and returns its node.
+// It returns nil if mark does not belong to this list.
+func (l *List[T]) InsertBefore(value T, mark *Node[T]) *Node[T] {
+	if mark == nil || mark.list != l {
+		return nil
+	}
+	return l.insertAfter(value, mark.prev)
+}
+
+// InsertAfter inserts a new value right after mark and returns its node.
+// It returns nil if mark does not belong to this list.
+func (l *List[T]) InsertAfter(value T, mark *Node[T]) *Node[T] {
+	if mark == nil || mark.list != l {
+		return nil
+	}
+	return l.insertAfter(value, mark)
+}
+
+// InsertAt inserts a new value so that it ends up at the given index (0 = front, Len() = back)
+func (l *List[T]) InsertAt(index int, value T) (*Node[T], error) {
+	if index < 0 || index > l.len {
+		return nil, fmt.Errorf("%w: %d with length %d", ErrIndexOutOfRange, index, l.len)
+	}
+	if index == l.len {
+		return l.PushBack(value), nil
+	}
+
+	// Walk from whichever end is closer
+	var curr *Node[T]
+	if index < l.len/2 {
+		curr = l.head
+		for i := 0; i < index; i++ {
+			curr = curr.next
+		}
+	} else {
+		curr = l.tail
+		for i := l.len - 1; i > index; i-- {
+			curr = curr.prev
+		}
+	}
+	return l.insertAfter(value, curr.prev), nil
+}
+
+// insertAfter links a new node after prev, or at the front when prev is nil
+func (l *List[T]) insertAfter(value T, prev *Node[T]) *Node[T] {
+	newNode := &Node[T]{Value: value, prev: prev, list: l}
+
+	if prev == nil {
+		newNode.next = l.head
 		l.head = newNode
-		return
+	} else {
+		newNode.next = prev.next
+		prev.next = newNode
 	}
 
-	// Traverse to the end of the list
-	curr := l.head
-	for curr.next != nil {
-		curr = curr.next
+	if newNode.next == nil {
+		l.tail = newNode
+	} else {
+		newNode.next.prev = newNode
 	}
 
-	// Append the new node
-	curr.next = newNode
+	l.len++
+	return newNode
 }
 
-// Remove deletes the first occurrence of a value from the list
-func (l *List) Remove(value int) {
-	if l.head == nil {
-		// List is empty, nothing to remove
-		return
+// FindFunc returns the first node whose value satisfies match, or nil if there is none
+func (l *List[T]) FindFunc(match func(T) bool) *Node[T] {
+	for curr := l.head; curr != nil; curr = curr.next {
+		if match(curr.Value) {
+			return curr
+		}
+	}
+	return nil
+}
+
+// RemoveFunc deletes the first value that satisfies match.
+// It reports whether a value was removed.
+func (l *List[T]) RemoveFunc(match func(T) bool) bool {
+	n := l.FindFunc(match)
+	if n == nil {
+		return false
+	}
+	l.RemoveNode(n)
+	return true
+}
+
+// Find returns the first node of l holding value, or nil if there is none
+func Find[T comparable](l *List[T], value T) *Node[T] {
+	return l.FindFunc(func(v T) bool { return v == value })
+}
+
+// Remove deletes the first occurrence of value from l.
+// It reports whether a value was removed.
+func Remove[T comparable](l *List[T], value T) bool {
+	return l.RemoveFunc(func(v T) bool { return v == value })
+}
+
+// RemoveNode unlinks n from the list in O(1) and returns its value.
+// Nodes that do not belong to this list are left untouched.
+func (l *List[T]) RemoveNode(n *Node[T]) T {
+	if n.list != l {
+		return n.Value
 	}
 
-	// If head contains the value, move head to next node
-	if l.head.data == value {
-		l.head = l.head.next
+	if n.prev == nil {
+		l.head = n.next
+	} else {
+		n.prev.next = n.next
+	}
+	if n.next == nil {
+		l.tail = n.prev
+	} else {
+		n.next.prev = n.prev
+	}
+
+	// Clear the links so a removed node cannot reach back into the list
+	n.next, n.prev, n.list = nil, nil, nil
+	l.len--
+	return n.Value
+}
+
+// MoveToFront moves n to the start of the list in O(1) without allocating.
+// Nodes that do not belong to this list are left untouched.
+func (l *List[T]) MoveToFront(n *Node[T]) {
+	if n.list != l || l.head == n {
 		return
 	}
 
-	// Traverse list to find the node before the target node
-	curr := l.head
-	for curr.next != nil && curr.next.data != value {
-		curr = curr.next
+	// Unlink n; it is not the head, so n.prev is never nil here
+	n.prev.next = n.next
+	if n.next == nil {
+		l.tail = n.prev
+	} else {
+		n.next.prev = n.prev
 	}
 
-	// Remove the node by skipping it
-	if curr.next != nil {
-		curr.next = curr.next.next
+	// Relink it in front of the old head
+	n.prev = nil
+	n.next = l.head
+	l.head.prev = n
+	l.head = n
+}
+
+// Reverse reverses the order of the list in place
+func (l *List[T]) Reverse() {
+	for curr := l.head; curr != nil; curr = curr.prev {
+		curr.next, curr.prev = curr.prev, curr.next
 	}
+	l.head, l.tail = l.tail, l.head
 }
 
-// PrintList prints all values in the list
-func PrintList(l *List) {
-	curr := l.head
-	for curr != nil {
-		fmt.Printf("%d ", curr.data)
-		curr = curr.next
+// All returns an iterator over the values from front to back:
+//
+//	for v := range list.All() { ... }
+func (l *List[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for curr := l.head; curr != nil; curr = curr.next {
+			if !yield(curr.Value) {
+				return
+			}
+		}
 	}
-	fmt.Println()
+}
+
+// Backward returns an iterator over the values from back to front
+func (l *List[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for curr := l.tail; curr != nil; curr = curr.prev {
+			if !yield(curr.Value) {
+				return
+			}
+		}
+	}
+}
+
+// String returns the values separated by spaces, e.g. "1 2 3 ",
+// so fmt.Println(list) prints the same line PrintList always did
+func (l *List[T]) String() string {
+	var sb strings.Builder
+	for v := range l.All() {
+		fmt.Fprintf(&sb, "%v ", v)
+	}
+	return sb.String()
+}
+
+// PrintList prints all values in the list.
+// It is the same as fmt.Println(l), which goes through List.String.
+func PrintList[T any](l *List[T]) {
+	fmt.Println(l)
 }
@@ -0,0 +1,205 @@
+package data
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+// checkList fails the test unless l holds want, walking the links in both directions
+func checkList[T comparable](t *testing.T, l *List[T], want ...T) {
+	t.Helper()
+	if l.Len() != len(want) {
+		t.Fatalf("Len = %d, want %d", l.Len(), len(want))
+	}
+	if got := slices.Collect(l.All()); !slices.Equal(got, want) {
+		t.Fatalf("All = %v, want %v", got, want)
+	}
+	back := slices.Clone(want)
+	slices.Reverse(back)
+	if got := slices.Collect(l.Backward()); !slices.Equal(got, back) {
+		t.Fatalf("Backward = %v, want %v", got, back)
+	}
+	if len(want) == 0 {
+		if l.Front() != nil || l.Back() != nil {
+			t.Fatal("empty list has a front or back node")
+		}
+		return
+	}
+	if l.Front().Prev() != nil || l.Back().Next() != nil {
+		t.Fatal("the ends of the list link past themselves")
+	}
+}
+
+func TestListPushPop(t *testing.T) {
+	var l List[int]
+	checkList(t, &l)
+	if _, ok := l.PopFront(); ok {
+		t.Fatal("PopFront on an empty list reported ok")
+	}
+	if _, ok := l.PopBack(); ok {
+		t.Fatal("PopBack on an empty list reported ok")
+	}
+
+	l.PushBack(2)
+	l.PushFront(1)
+	l.Add(3)
+	l.PushBack(4)
+	checkList(t, &l, 1, 2, 3, 4)
+
+	if v, ok := l.PopFront(); !ok || v != 1 {
+		t.Fatalf("PopFront = %d, %v; want 1, true", v, ok)
+	}
+	if v, ok := l.PopBack(); !ok || v != 4 {
+		t.Fatalf("PopBack = %d, %v; want 4, true", v, ok)
+	}
+	checkList(t, &l, 2, 3)
+
+	l.PopBack()
+	l.PopBack()
+	checkList(t, &l)
+}
+
+func TestListInsert(t *testing.T) {
+	var l List[string]
+	b := l.PushBack("b")
+	l.InsertBefore("a", b)
+	d := l.InsertAfter("d", b)
+	l.InsertAfter("c", b)
+	l.InsertAfter("e", d)
+	checkList(t, &l, "a", "b", "c", "d", "e")
+
+	// Marks from another list, removed marks and nil are refused
+	var other List[string]
+	foreign := other.PushBack("x")
+	l.RemoveNode(d)
+	for _, mark := range []*Node[string]{foreign, d, nil} {
+		if l.InsertBefore("?", mark) != nil || l.InsertAfter("?", mark) != nil {
+			t.Fatalf("insert next to %v succeeded", mark)
+		}
+	}
+	checkList(t, &l, "a", "b", "c", "e")
+	checkList(t, &other, "x")
+
+	for _, tt := range []struct {
+		index int
+		value string
+	}{{0, "front"}, {5, "back"}, {3, "mid"}, {4, "mid2"}} {
+		n, err := l.InsertAt(tt.index, tt.value)
+		if err != nil || n.Value != tt.value {
+			t.Fatalf("InsertAt(%d) = %v, %v", tt.index, n, err)
+		}
+	}
+	checkList(t, &l, "front", "a", "b", "mid", "mid2", "c", "e", "back")
+
+	for _, index := range []int{-1, l.Len() + 1} {
+		if _, err := l.InsertAt(index, "?"); !errors.Is(err, ErrIndexOutOfRange) {
+			t.Fatalf("InsertAt(%d) error = %v, want ErrIndexOutOfRange", index, err)
+		}
+	}
+}
+
+func TestListRemove(t *testing.T) {
+	var l List[int]
+	for _, v := range []int{1, 2, 3, 2, 4} {
+		l.PushBack(v)
+	}
+	if !Remove(&l, 2) || !Remove(&l, 4) || Remove(&l, 9) {
+		t.Fatal("Remove reported the wrong result")
+	}
+	checkList(t, &l, 1, 3, 2)
+
+	if n := Find(&l, 3); n == nil || n.Next().Value != 2 || n.Prev().Value != 1 {
+		t.Fatalf("Find(3) = %v", n)
+	}
+	if Find(&l, 9) != nil {
+		t.Fatal("Find(9) found a node")
+	}
+
+	// Removing a node of another list leaves both lists alone
+	var other List[int]
+	foreign := other.PushBack(1)
+	l.RemoveNode(foreign)
+	checkList(t, &l, 1, 3, 2)
+	checkList(t, &other, 1)
+
+	l.MoveToFront(l.Back())
+	checkList(t, &l, 2, 1, 3)
+	l.MoveToFront(l.Front())
+	checkList(t, &l, 2, 1, 3)
+}
+
+// TestListAny builds lists of types that cannot be compared with ==
+func TestListAny(t *testing.T) {
+	var l List[[]int]
+	l.PushBack([]int{1})
+	l.PushBack([]int{2, 3})
+	l.PushBack(nil)
+
+	n := l.FindFunc(func(v []int) bool { return len(v) == 2 })
+	if n == nil || !slices.Equal(n.Value, []int{2, 3}) {
+		t.Fatalf("FindFunc = %v", n)
+	}
+	if !l.RemoveFunc(func(v []int) bool { return v == nil }) || l.Len() != 2 {
+		t.Fatalf("RemoveFunc(nil) left %d values", l.Len())
+	}
+	if got := l.String(); got != "[1] [2 3] " {
+		t.Fatalf("String = %q", got)
+	}
+
+	var funcs List[func() int]
+	funcs.PushBack(func() int { return 1 })
+	if f, _ := funcs.PopFront(); f() != 1 {
+		t.Fatal("wrong func popped")
+	}
+}
+
+func TestListReverse(t *testing.T) {
+	for n := range 5 {
+		var l List[int]
+		want := make([]int, n)
+		for i := range n {
+			l.PushBack(i)
+			want[n-1-i] = i
+		}
+		l.Reverse()
+		checkList(t, &l, want...)
+	}
+}
+
+func TestListIterStop(t *testing.T) {
+	var l List[int]
+	for i := range 10 {
+		l.PushBack(i)
+	}
+	var got []int
+	for v := range l.All() {
+		if v == 3 {
+			break
+		}
+		got = append(got, v)
+	}
+	for v := range l.Backward() {
+		if v == 7 {
+			break
+		}
+		got = append(got, v)
+	}
+	if want := []int{0, 1, 2, 9, 8}; !slices.Equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+// TestListString keeps the output of the old PrintList: every value followed by a space
+func TestListString(t *testing.T) {
+	var l List[int]
+	if got := l.String(); got != "" {
+		t.Fatalf("empty String = %q", got)
+	}
+	for _, v := range []int{1, 3, 4} {
+		l.Add(v)
+	}
+	if got := l.String(); got != "1 3 4 " {
+		t.Fatalf("String = %q, want %q", got, "1 3 4 ")
+	}
+}
@@ -0,0 +1,123 @@
+package data
+
+import "sync"
+
+// LRUCache is a fixed-size cache that evicts the least recently used entry when it is full.
+//
+// A map finds entries by key, and a List keeps the keys in order of use:
+// every Get or Put moves the key to the front, so the key at the back is the one to evict.
+// Both are O(1), so no operation ever walks the list.
+//
+// Time Complexity: Get, Peek, Put, Delete O(1)
+// Space Complexity: O(capacity)
+//
+// ✅ Safe for concurrent use by multiple goroutines.
+type LRUCache[K comparable, V any] struct {
+	mu       sync.Mutex // Get changes the order too, so a plain Mutex is enough
+	capacity int
+	order    List[K] // Front = most recently used
+	items    map[K]*lruEntry[K, V]
+	onEvict  EvictionFunc[K, V]
+	stats    CacheStats
+}
+
+type lruEntry[K comparable, V any] struct {
+	value V
+	node  *Node[K] // The key's node in order
+}
+
+// NewLRUCache creates an empty cache holding at most capacity entries (at least 1).
+//
+// 🔹 capacity: maximum number of entries
+// 🔹 onEvict: called for each entry evicted to make room; may be nil
+func NewLRUCache[K comparable, V any](capacity int, onEvict EvictionFunc[K, V]) *LRUCache[K, V] {
+	capacity = max(capacity, 1)
+	return &LRUCache[K, V]{
+		capacity: capacity,
+		items:    make(map[K]*lruEntry[K, V], capacity),
+		onEvict:  onEvict,
+	}
+}
+
+// Get returns the value for key and marks it as most recently used; ok is false if it is not cached.
+func (c *LRUCache[K, V]) Get(key K) (value V, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.items[key]
+	if !ok {
+		c.stats.Misses++
+		return value, false
+	}
+	c.stats.Hits++
+	c.order.MoveToFront(e.node)
+	return e.value, true
+}
+
+// Peek returns the value for key without changing its position or the stats.
+func (c *LRUCache[K, V]) Peek(key K) (value V, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.items[key]; ok {
+		return e.value, true
+	}
+	return value, false
+}
+
+// Put stores value for key and marks it as most recently used.
+// If the cache is full, the least recently used entry is evicted first.
+func (c *LRUCache[K, V]) Put(key K, value V) {
+	var out []evicted[K, V]
+
+	c.mu.Lock()
+	if e, ok := c.items[key]; ok {
+		e.value = value
+		c.order.MoveToFront(e.node)
+	} else {
+		if len(c.items) >= c.capacity {
+			out = append(out, c.evictOldest())
+		}
+		c.items[key] = &lruEntry[K, V]{value: value, node: c.order.PushFront(key)}
+	}
+	c.mu.Unlock()
+
+	notifyEvicted(c.onEvict, out)
+}
+
+// evictOldest removes the entry at the back of the list; the cache must not be empty.
+func (c *LRUCache[K, V]) evictOldest() evicted[K, V] {
+	key := c.order.RemoveNode(c.order.Back())
+	e := c.items[key]
+	delete(c.items, key)
+	c.stats.Evictions++
+	return evicted[K, V]{key: key, value: e.value, reason: EvictedCapacity}
+}
+
+// Delete removes key and reports whether it was cached. The EvictionFunc is not called.
+func (c *LRUCache[K, V]) Delete(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.items[key]
+	if !ok {
+		return false
+	}
+	c.order.RemoveNode(e.node)
+	delete(c.items, key)
+	return true
+}
+
+// Len returns the number of cached entries.
+func (c *LRUCache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.items)
+}
+
+// Stats returns a snapshot of the hit, miss and eviction counters.
+func (c *LRUCache[K, V]) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
@@ -0,0 +1,130 @@
+package data
+
+import "cmp"
+
+// Mergesort sorts a slice of integers in ascending order using the top-down Merge Sort algorithm.
+//
+// 🔹 items: slice of integers to be sorted
+//
+// Merge Sort is a divide-and-conquer sorting algorithm:
+// 1. Split the slice into two halves.
+// 2. Recursively sort each half.
+// 3. Merge the two sorted halves back together.
+//
+// Time Complexity: O(n log n) for all cases (best, average, worst)
+// Space Complexity: O(n) for the merge buffer
+//
+// ✅ Stable: equal elements keep their original relative order, so it is safe for multi-key sorts.
+func Mergesort(items []int) {
+	MergesortOrdered(items)
+}
+
+// MergesortOrdered is the generic form of Mergesort for any ordered element type.
+func MergesortOrdered[T cmp.Ordered](items []T) {
+	MergesortFunc(items, cmp.Compare[T])
+}
+
+// MergesortFunc sorts items in ascending order as determined by the compare function.
+//
+// 🔹 items: slice of any type to be sorted
+// 🔹 compare: returns a negative number when a < b, zero when a == b and a positive number when a > b
+//
+// The sort is stable, so sorting orders by customer and then by total
+// leaves orders with the same total grouped by customer.
+func MergesortFunc[T any](items []T, compare func(a, b T) int) {
+	if len(items) < 2 {
+		return
+	}
+
+	// Allocate the merge buffer once and reuse it at every level of recursion
+	buf := make([]T, len(items))
+	mergesortRec(items, buf, compare)
+}
+
+// mergesortRec sorts items using buf (same length) as scratch space.
+func mergesortRec[T any](items, buf []T, compare func(a, b T) int) {
+	if len(items) < 2 {
+		return
+	}
+
+	mid := len(items) / 2
+	mergesortRec(items[:mid], buf[:mid], compare)
+	mergesortRec(items[mid:], buf[mid:], compare)
+
+	// Already in order: the last element on the left is not greater than the first on the right
+	if compare(items[mid-1], items[mid]) <= 0 {
+		return
+	}
+
+	copy(buf, items)
+	merge(items, buf[:mid], buf[mid:], compare)
+}
+
+// BottomUpMergesort sorts a slice of integers in ascending order using the bottom-up Merge Sort algorithm.
+//
+// 🔹 items: slice of integers to be sorted
+//
+// Instead of recursing, bottom-up Merge Sort merges runs of width 1, then 2, 4, 8, ...
+// until a single run covers the whole slice.
+//
+// Time Complexity: O(n log n) for all cases
+// Space Complexity: O(n) for the merge buffer, no recursion
+//
+// ✅ Stable, like Mergesort.
+func BottomUpMergesort(items []int) {
+	BottomUpMergesortOrdered(items)
+}
+
+// BottomUpMergesortOrdered is the generic form of BottomUpMergesort for any ordered element type.
+func BottomUpMergesortOrdered[T cmp.Ordered](items []T) {
+	BottomUpMergesortFunc(items, cmp.Compare[T])
+}
+
+// BottomUpMergesortFunc sorts items in ascending order as determined by the compare function.
+//
+// 🔹 items: slice of any type to be sorted
+// 🔹 compare: returns a negative number when a < b, zero when a == b and a positive number when a > b
+func BottomUpMergesortFunc[T any](items []T, compare func(a, b T) int) {
+	n := len(items)
+	if n < 2 {
+		return
+	}
+
+	// src holds the runs being read, dst receives the merged runs; they swap after each pass
+	src, dst := items, make([]T, n)
+
+	for width := 1; width < n; width *= 2 {
+		for low := 0; low < n; low += 2 * width {
+			mid := min(low+width, n)
+			high := min(low+2*width, n)
+			merge(dst[low:high], src[low:mid], src[mid:high], compare)
+		}
+		src, dst = dst, src
+	}
+
+	// After an odd number of passes the sorted data lives in the buffer
+	if &src[0] != &items[0] {
+		copy(items, src)
+	}
+}
+
+// merge merges the sorted slices left and right into dst, which must have room for both.
+// Ties are taken from left first, which is what makes every merge-based sort here stable.
+func merge[T any](dst, left, right []T, compare func(a, b T) int) {
+	i, j, k := 0, 0, 0
+
+	for i < len(left) && j < len(right) {
+		if compare(right[j], left[i]) < 0 {
+			dst[k] = right[j]
+			j++
+		} else {
+			dst[k] = left[i]
+			i++
+		}
+		k++
+	}
+
+	// Copy whatever remains of either side
+	k += copy(dst[k:], left[i:])
+	copy(dst[k:], right[j:])
+}
@@ -0,0 +1,101 @@
+package data
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+// record is a sort key plus the position it had in the input
+type record struct {
+	key, seq int
+}
+
+func byKey(a, b record) int { return cmp.Compare(a.key, b.key) }
+
+// stableSorts are the sorts that promise to keep equal elements in input order
+var stableSorts = []struct {
+	name string
+	sort func([]record, func(a, b record) int)
+}{
+	{"Mergesort", MergesortFunc[record]},
+	{"BottomUpMergesort", BottomUpMergesortFunc[record]},
+	{"Timsort", TimsortFunc[record]},
+}
+
+// TestStableSorts sorts records with many duplicate keys and checks that
+// every key's records are still in seq order afterwards
+func TestStableSorts(t *testing.T) {
+	for _, s := range stableSorts {
+		for _, kind := range inputKinds {
+			for _, n := range testSizes {
+				t.Run(fmt.Sprintf("%s/%s/%d", s.name, kind.name, n), func(t *testing.T) {
+					// Squeeze the keys into a few values so most of them repeat
+					keys := kind.gen(newRand(), n)
+					records := make([]record, n)
+					for i, k := range keys {
+						records[i] = record{key: k % 10, seq: i}
+					}
+
+					s.sort(records, byKey)
+
+					for i := 1; i < len(records); i++ {
+						prev, cur := records[i-1], records[i]
+						if prev.key > cur.key {
+							t.Fatalf("index %d: key %d after %d", i, cur.key, prev.key)
+						}
+						if prev.key == cur.key && prev.seq > cur.seq {
+							t.Fatalf("index %d: key %d has seq %d after %d, equal keys were reordered", i, cur.key, cur.seq, prev.seq)
+						}
+					}
+				})
+			}
+		}
+	}
+}
+
+// TestStableSortsMultiKey sorts by a secondary key first and by the primary key second,
+// which only gives a correct multi-key order when the second sort is stable
+func TestStableSortsMultiKey(t *testing.T) {
+	r := newRand()
+	orders := make([]record, 5000)
+	for i := range orders {
+		orders[i] = record{key: r.IntN(50), seq: r.IntN(50)} // key = total, seq = customer
+	}
+	want := slices.Clone(orders)
+	slices.SortFunc(want, func(a, b record) int {
+		return cmp.Or(cmp.Compare(a.key, b.key), cmp.Compare(a.seq, b.seq))
+	})
+
+	for _, s := range stableSorts {
+		t.Run(s.name, func(t *testing.T) {
+			got := slices.Clone(orders)
+			s.sort(got, func(a, b record) int { return cmp.Compare(a.seq, b.seq) })
+			s.sort(got, byKey)
+			if !slices.Equal(got, want) {
+				t.Fatal("sorting by customer and then by total did not group equal totals by customer")
+			}
+		})
+	}
+}
+
+func TestStableSortsInts(t *testing.T) {
+	sorts := map[string]func([]int){
+		"Mergesort":         Mergesort,
+		"BottomUpMergesort": BottomUpMergesort,
+		"Timsort":           Timsort,
+	}
+	for name, sort := range sorts {
+		for _, kind := range inputKinds {
+			for _, n := range testSizes {
+				t.Run(fmt.Sprintf("%s/%s/%d", name, kind.name, n), func(t *testing.T) {
+					input := kind.gen(newRand(), n)
+					got := slices.Clone(input)
+					sort(got)
+					checkSorted(t, input, got)
+				})
+			}
+		}
+	}
+}
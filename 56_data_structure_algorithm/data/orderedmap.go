@@ -0,0 +1,339 @@
+package data
+
+import (
+	"cmp"
+	"iter"
+)
+
+// OrderedMap is a map that keeps its keys sorted, backed by an AVL tree
+// (a self-balancing binary search tree).
+//
+// After every Put and Delete, the heights of the two subtrees of any node differ by at most one,
+// which keeps the tree height at O(log n). Every node also stores the size of its subtree,
+// which makes Rank and Select O(log n) as well.
+//
+// Time Complexity: Get, Put, Delete, Min, Max, Floor, Ceiling, Rank, Select O(log n)
+// Space Complexity: O(n)
+//
+// ✅ Use it instead of re-sorting the keys of a regular map every time they are needed in order.
+// The zero value is an empty map ready to use: var customers data.OrderedMap[int, Customer]
+type OrderedMap[K cmp.Ordered, V any] struct {
+	root *treeNode[K, V]
+}
+
+// treeNode is a node of the AVL tree
+type treeNode[K cmp.Ordered, V any] struct {
+	key         K
+	value       V
+	left, right *treeNode[K, V]
+	height      int // Height of the subtree rooted here (a leaf has height 1)
+	size        int // Number of nodes in the subtree rooted here
+}
+
+// Len returns the number of keys in the map.
+func (m *OrderedMap[K, V]) Len() int {
+	return m.root.getSize()
+}
+
+// Get returns the value stored for key; ok is false if the key is not present.
+func (m *OrderedMap[K, V]) Get(key K) (value V, ok bool) {
+	n := m.root
+	for n != nil {
+		switch c := cmp.Compare(key, n.key); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return n.value, true
+		}
+	}
+	return value, false
+}
+
+// Put stores value for key, replacing any previous value.
+func (m *OrderedMap[K, V]) Put(key K, value V) {
+	m.root = m.root.put(key, value)
+}
+
+// Delete removes key from the map and reports whether it was present.
+func (m *OrderedMap[K, V]) Delete(key K) bool {
+	var deleted bool
+	m.root, deleted = m.root.delete(key)
+	return deleted
+}
+
+// Min returns the smallest key and its value; ok is false if the map is empty.
+func (m *OrderedMap[K, V]) Min() (key K, value V, ok bool) {
+	if m.root == nil {
+		return key, value, false
+	}
+	n := m.root.min()
+	return n.key, n.value, true
+}
+
+// Max returns the largest key and its value; ok is false if the map is empty.
+func (m *OrderedMap[K, V]) Max() (key K, value V, ok bool) {
+	n := m.root
+	if n == nil {
+		return key, value, false
+	}
+	for n.right != nil {
+		n = n.right
+	}
+	return n.key, n.value, true
+}
+
+// Floor returns the largest key less than or equal to key; ok is false if there is none.
+func (m *OrderedMap[K, V]) Floor(key K) (k K, v V, ok bool) {
+	var best *treeNode[K, V]
+	for n := m.root; n != nil; {
+		switch c := cmp.Compare(key, n.key); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			// n is a candidate, but there may be a larger one on the right
+			best = n
+			n = n.right
+		default:
+			return n.key, n.value, true
+		}
+	}
+	if best == nil {
+		return k, v, false
+	}
+	return best.key, best.value, true
+}
+
+// Ceiling returns the smallest key greater than or equal to key; ok is false if there is none.
+func (m *OrderedMap[K, V]) Ceiling(key K) (k K, v V, ok bool) {
+	var best *treeNode[K, V]
+	for n := m.root; n != nil; {
+		switch c := cmp.Compare(key, n.key); {
+		case c < 0:
+			// n is a candidate, but there may be a smaller one on the left
+			best = n
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return n.key, n.value, true
+		}
+	}
+	if best == nil {
+		return k, v, false
+	}
+	return best.key, best.value, true
+}
+
+// Rank returns the number of keys strictly less than key.
+// If key is present, that is its index in sorted order.
+func (m *OrderedMap[K, V]) Rank(key K) int {
+	rank := 0
+	for n := m.root; n != nil; {
+		switch c := cmp.Compare(key, n.key); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			// Everything in the left subtree and n itself are smaller
+			rank += n.left.getSize() + 1
+			n = n.right
+		default:
+			return rank + n.left.getSize()
+		}
+	}
+	return rank
+}
+
+// Select returns the key with the given rank (0 = smallest); ok is false if i is out of range.
+func (m *OrderedMap[K, V]) Select(i int) (key K, value V, ok bool) {
+	if i < 0 || i >= m.Len() {
+		return key, value, false
+	}
+	n := m.root
+	for {
+		leftSize := n.left.getSize()
+		switch {
+		case i < leftSize:
+			n = n.left
+		case i > leftSize:
+			i -= leftSize + 1
+			n = n.right
+		default:
+			return n.key, n.value, true
+		}
+	}
+}
+
+// All returns an iterator over all keys and values in ascending key order:
+//
+//	for id, customer := range customers.All() { ... }
+func (m *OrderedMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.root.ascend(nil, nil, yield)
+	}
+}
+
+// Range returns an iterator over the keys in [from, to) and their values in ascending key order.
+func (m *OrderedMap[K, V]) Range(from, to K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if cmp.Less(from, to) {
+			m.root.ascend(&from, &to, yield)
+		}
+	}
+}
+
+// ascend calls yield for every key in [from, to) in order. A nil bound is unbounded.
+// It returns false as soon as yield asks to stop.
+func (n *treeNode[K, V]) ascend(from, to *K, yield func(K, V) bool) bool {
+	if n == nil {
+		return true
+	}
+
+	aboveFrom := from == nil || cmp.Compare(n.key, *from) >= 0
+	belowTo := to == nil || cmp.Less(n.key, *to)
+
+	// Skip the left subtree entirely when all of it is below from
+	if aboveFrom && !n.left.ascend(from, to, yield) {
+		return false
+	}
+	if aboveFrom && belowTo && !yield(n.key, n.value) {
+		return false
+	}
+	// Skip the right subtree entirely when all of it is at or above to
+	if belowTo {
+		return n.right.ascend(from, to, yield)
+	}
+	return true
+}
+
+// put inserts or replaces key in the subtree and returns its new (balanced) root.
+func (n *treeNode[K, V]) put(key K, value V) *treeNode[K, V] {
+	if n == nil {
+		return &treeNode[K, V]{key: key, value: value, height: 1, size: 1}
+	}
+
+	switch c := cmp.Compare(key, n.key); {
+	case c < 0:
+		n.left = n.left.put(key, value)
+	case c > 0:
+		n.right = n.right.put(key, value)
+	default:
+		n.value = value
+		return n
+	}
+
+	return n.rebalance()
+}
+
+// delete removes key from the subtree and returns its new (balanced) root.
+func (n *treeNode[K, V]) delete(key K) (*treeNode[K, V], bool) {
+	if n == nil {
+		return nil, false
+	}
+
+	var deleted bool
+	switch c := cmp.Compare(key, n.key); {
+	case c < 0:
+		n.left, deleted = n.left.delete(key)
+	case c > 0:
+		n.right, deleted = n.right.delete(key)
+	default:
+		// Zero or one child: replace the node by that child
+		if n.left == nil {
+			return n.right, true
+		}
+		if n.right == nil {
+			return n.left, true
+		}
+
+		// Two children: replace the node by its successor, the smallest key on the right
+		successor := n.right.min()
+		successor.right = n.right.deleteMin()
+		successor.left = n.left
+		n = successor
+		deleted = true
+	}
+
+	return n.rebalance(), deleted
+}
+
+// deleteMin removes the smallest node of the subtree and returns its new root.
+func (n *treeNode[K, V]) deleteMin() *treeNode[K, V] {
+	if n.left == nil {
+		return n.right
+	}
+	n.left = n.left.deleteMin()
+	return n.rebalance()
+}
+
+// min returns the node with the smallest key in the subtree.
+func (n *treeNode[K, V]) min() *treeNode[K, V] {
+	for n.left != nil {
+		n = n.left
+	}
+	return n
+}
+
+// rebalance updates height and size and applies the AVL rotations if the node is unbalanced.
+func (n *treeNode[K, V]) rebalance() *treeNode[K, V] {
+	n.update()
+
+	switch balance := n.left.getHeight() - n.right.getHeight(); {
+	case balance > 1:
+		// Left-right case: rotate the left child first
+		if n.left.left.getHeight() < n.left.right.getHeight() {
+			n.left = n.left.rotateLeft()
+		}
+		return n.rotateRight()
+	case balance < -1:
+		// Right-left case: rotate the right child first
+		if n.right.right.getHeight() < n.right.left.getHeight() {
+			n.right = n.right.rotateRight()
+		}
+		return n.rotateLeft()
+	}
+	return n
+}
+
+// rotateLeft lifts the right child above n and returns it.
+func (n *treeNode[K, V]) rotateLeft() *treeNode[K, V] {
+	r := n.right
+	n.right = r.left
+	r.left = n
+	n.update()
+	r.update()
+	return r
+}
+
+// rotateRight lifts the left child above n and returns it.
+func (n *treeNode[K, V]) rotateRight() *treeNode[K, V] {
+	l := n.left
+	n.left = l.right
+	l.right = n
+	n.update()
+	l.update()
+	return l
+}
+
+// update recomputes height and size from the children.
+func (n *treeNode[K, V]) update() {
+	n.height = 1 + max(n.left.getHeight(), n.right.getHeight())
+	n.size = 1 + n.left.getSize() + n.right.getSize()
+}
+
+// getHeight returns the height of the subtree; an empty subtree has height 0.
+func (n *treeNode[K, V]) getHeight() int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+// getSize returns the number of nodes in the subtree.
+func (n *treeNode[K, V]) getSize() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
@@ -0,0 +1,209 @@
+package data
+
+import (
+	"fmt"
+	"math/bits"
+	"slices"
+	"testing"
+)
+
+// checkTree fails the test if the subtree of n breaks the search order, or has a wrong
+// height, a wrong size or an AVL imbalance; it returns the height and size it found
+func checkTree(t *testing.T, n *treeNode[int, string], lo, hi *int) (height, size int) {
+	t.Helper()
+	if n == nil {
+		return 0, 0
+	}
+	if (lo != nil && n.key <= *lo) || (hi != nil && n.key >= *hi) {
+		t.Fatalf("key %v is out of order", n.key)
+	}
+	lh, ls := checkTree(t, n.left, lo, &n.key)
+	rh, rs := checkTree(t, n.right, &n.key, hi)
+	if lh-rh > 1 || rh-lh > 1 {
+		t.Fatalf("node %v is unbalanced: heights %d and %d", n.key, lh, rh)
+	}
+	height, size = 1+max(lh, rh), 1+ls+rs
+	if n.height != height || n.size != size {
+		t.Fatalf("node %v stores height %d, size %d; want %d, %d", n.key, n.height, n.size, height, size)
+	}
+	return height, size
+}
+
+// modelMap is what an OrderedMap must behave like: a map plus its sorted keys
+type modelMap struct {
+	values map[int]string
+	keys   []int
+}
+
+func (mm *modelMap) put(k int, v string) {
+	if _, ok := mm.values[k]; !ok {
+		i, _ := slices.BinarySearch(mm.keys, k)
+		mm.keys = slices.Insert(mm.keys, i, k)
+	}
+	mm.values[k] = v
+}
+
+func (mm *modelMap) delete(k int) bool {
+	if _, ok := mm.values[k]; !ok {
+		return false
+	}
+	delete(mm.values, k)
+	i, _ := slices.BinarySearch(mm.keys, k)
+	mm.keys = slices.Delete(mm.keys, i, i+1)
+	return true
+}
+
+// checkQueries compares the order queries of m with the model for keys around the stored ones
+func checkQueries(t *testing.T, m *OrderedMap[int, string], mm *modelMap) {
+	t.Helper()
+	for k := -2; k <= 1002; k++ {
+		i, found := slices.BinarySearch(mm.keys, k)
+
+		if got := m.Rank(k); got != i {
+			t.Fatalf("Rank(%d) = %d, want %d", k, got, i)
+		}
+
+		fk, fv, ok := m.Floor(k)
+		switch {
+		case found:
+			if !ok || fk != k || fv != mm.values[k] {
+				t.Fatalf("Floor(%d) = %d, %q, %v; want the key itself", k, fk, fv, ok)
+			}
+		case i == 0:
+			if ok {
+				t.Fatalf("Floor(%d) = %d, want none", k, fk)
+			}
+		case !ok || fk != mm.keys[i-1]:
+			t.Fatalf("Floor(%d) = %d, %v; want %d", k, fk, ok, mm.keys[i-1])
+		}
+
+		ck, _, ok := m.Ceiling(k)
+		switch {
+		case i == len(mm.keys):
+			if ok {
+				t.Fatalf("Ceiling(%d) = %d, want none", k, ck)
+			}
+		case !ok || ck != mm.keys[i]:
+			t.Fatalf("Ceiling(%d) = %d, %v; want %d", k, ck, ok, mm.keys[i])
+		}
+	}
+
+	for i := -1; i <= len(mm.keys); i++ {
+		k, v, ok := m.Select(i)
+		if i < 0 || i == len(mm.keys) {
+			if ok {
+				t.Fatalf("Select(%d) = %d, want none", i, k)
+			}
+			continue
+		}
+		if !ok || k != mm.keys[i] || v != mm.values[k] {
+			t.Fatalf("Select(%d) = %d, %q, %v; want %d", i, k, v, ok, mm.keys[i])
+		}
+	}
+}
+
+func TestOrderedMap(t *testing.T) {
+	r := newRand()
+	var m OrderedMap[int, string]
+	mm := &modelMap{values: make(map[int]string)}
+
+	for step := range 3000 {
+		k := r.IntN(1000)
+		if r.IntN(3) == 0 {
+			if got, want := m.Delete(k), mm.delete(k); got != want {
+				t.Fatalf("step %d: Delete(%d) = %v, want %v", step, k, got, want)
+			}
+		} else {
+			v := fmt.Sprint(step)
+			m.Put(k, v)
+			mm.put(k, v)
+		}
+
+		height, size := checkTree(t, m.root, nil, nil)
+		if size != len(mm.keys) || m.Len() != size {
+			t.Fatalf("step %d: %d nodes, Len %d; want %d", step, size, m.Len(), len(mm.keys))
+		}
+		// An AVL tree is at most about 1.44·log2(n) high
+		if limit := 2 * (bits.Len(uint(size)) + 1); height > limit {
+			t.Fatalf("step %d: height %d for %d keys", step, height, size)
+		}
+		if v, ok := m.Get(k); ok != (mm.values[k] != "") || v != mm.values[k] {
+			t.Fatalf("step %d: Get(%d) = %q, %v; want %q", step, k, v, ok, mm.values[k])
+		}
+
+		if step%100 == 0 {
+			checkQueries(t, &m, mm)
+		}
+	}
+	checkQueries(t, &m, mm)
+
+	var all []int
+	for k, v := range m.All() {
+		if v != mm.values[k] {
+			t.Fatalf("All yields %d = %q, want %q", k, v, mm.values[k])
+		}
+		all = append(all, k)
+	}
+	if !slices.Equal(all, mm.keys) {
+		t.Fatalf("All is not in key order:\n got %v\nwant %v", head(all), head(mm.keys))
+	}
+
+	if k, _, ok := m.Min(); !ok || k != mm.keys[0] {
+		t.Fatalf("Min = %d, want %d", k, mm.keys[0])
+	}
+	if k, _, ok := m.Max(); !ok || k != mm.keys[len(mm.keys)-1] {
+		t.Fatalf("Max = %d, want %d", k, mm.keys[len(mm.keys)-1])
+	}
+
+	// Delete everything; the tree stays valid down to empty
+	for _, k := range slices.Clone(mm.keys) {
+		if !m.Delete(k) {
+			t.Fatalf("Delete(%d) = false", k)
+		}
+		checkTree(t, m.root, nil, nil)
+	}
+	if _, _, ok := m.Min(); ok || m.Len() != 0 {
+		t.Fatal("map not empty after deleting every key")
+	}
+}
+
+func TestOrderedMapRange(t *testing.T) {
+	var m OrderedMap[int, string]
+	var keys []int
+	for k := 0; k < 200; k += 3 {
+		m.Put(k, fmt.Sprint(k))
+		keys = append(keys, k)
+	}
+
+	for _, tt := range [][2]int{{0, 200}, {10, 20}, {9, 21}, {-5, 4}, {198, 500}, {50, 50}, {60, 40}, {1, 2}} {
+		from, to := tt[0], tt[1]
+		var want []int
+		for _, k := range keys {
+			if k >= from && k < to {
+				want = append(want, k)
+			}
+		}
+		var got []int
+		for k, v := range m.Range(from, to) {
+			if v != fmt.Sprint(k) {
+				t.Fatalf("Range(%d, %d) yields %d = %q", from, to, k, v)
+			}
+			got = append(got, k)
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("Range(%d, %d) = %v, want %v", from, to, got, want)
+		}
+	}
+
+	// Stopping early
+	var got []int
+	for k := range m.Range(30, 100) {
+		if len(got) == 3 {
+			break
+		}
+		got = append(got, k)
+	}
+	if !slices.Equal(got, []int{30, 33, 36}) {
+		t.Fatalf("first three of Range(30, 100) = %v", got)
+	}
+}
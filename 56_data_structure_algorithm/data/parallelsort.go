@@ -0,0 +1,185 @@
+package data
+
+import (
+	"cmp"
+	"math/bits"
+	"runtime"
+	"sync"
+)
+
+const (
+	// parallelCutoff is the smallest partition that is worth handing to another goroutine.
+	// Below it the cost of scheduling a goroutine outweighs the work it would do.
+	parallelCutoff = 4096
+
+	// insertionCutoff is the partition size at which the parallel sorts switch to Insertionsort.
+	insertionCutoff = 12
+)
+
+// workerPool bounds how many goroutines a parallel sort may run at the same time.
+// It is a counting semaphore: a goroutine may only be started after a token is put into sem.
+type workerPool struct {
+	sem chan struct{}
+	wg  sync.WaitGroup
+}
+
+// newWorkerPool creates a pool that allows up to GOMAXPROCS extra goroutines.
+func newWorkerPool() *workerPool {
+	return &workerPool{sem: make(chan struct{}, runtime.GOMAXPROCS(0))}
+}
+
+// run executes task in a new goroutine if a worker is free, otherwise in the calling goroutine.
+// Never blocking on a full pool is what keeps the recursion from deadlocking.
+func (p *workerPool) run(task func()) {
+	select {
+	case p.sem <- struct{}{}:
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			defer func() { <-p.sem }()
+			task()
+		}()
+	default:
+		task()
+	}
+}
+
+// wait blocks until every goroutine started by the pool has finished.
+func (p *workerPool) wait() {
+	p.wg.Wait()
+}
+
+// ParallelQuicksort sorts a slice of integers in ascending order using Quick Sort on several goroutines.
+//
+// 🔹 items: slice of integers to be sorted
+//
+// After each partition step, the two halves are independent, so one of them can be
+// sorted by another goroutine while the current goroutine keeps working on the other.
+// Partitions above parallelCutoff are handed to a bounded worker pool (one worker per CPU),
+// partitions below insertionCutoff are finished with Insertionsort.
+// Like Quicksort, a partition that is still being split after 2·log2(n) levels is
+// finished with heapsort on the goroutine that reached it.
+//
+// Time Complexity:
+// - Average case: O(n log n) work, spread over GOMAXPROCS goroutines
+// - Worst case: O(n log n) (thanks to the heapsort fallback)
+// Space Complexity: O(log n) recursion per goroutine
+//
+// ⚠️ Not stable. Only faster than Quicksort for large slices (tens of thousands of elements).
+func ParallelQuicksort(items []int) {
+	ParallelQuicksortOrdered(items)
+}
+
+// ParallelQuicksortOrdered is the generic form of ParallelQuicksort for any ordered element type.
+func ParallelQuicksortOrdered[T cmp.Ordered](items []T) {
+	ParallelQuicksortFunc(items, cmp.Compare[T])
+}
+
+// ParallelQuicksortFunc sorts items in ascending order as determined by the compare function.
+//
+// 🔹 items: slice of any type to be sorted
+// 🔹 compare: returns a negative number when a < b, zero when a == b and a positive number when a > b
+func ParallelQuicksortFunc[T any](items []T, compare func(a, b T) int) {
+	pool := newWorkerPool()
+	depth := 2 * bits.Len(uint(len(items)))
+	parallelQuicksort(pool, items, compare, depth)
+	pool.wait()
+}
+
+// parallelQuicksort is the recursive part of ParallelQuicksortFunc.
+// depth is the number of partition levels left before it switches to heapsort.
+func parallelQuicksort[T any](pool *workerPool, items []T, compare func(a, b T) int, depth int) {
+	for len(items) > insertionCutoff {
+		if depth == 0 {
+			// The pivots are bad for this input: stop splitting, and stop using the pool
+			HeapsortFunc(items, compare)
+			return
+		}
+		depth--
+
+		pivot := medianOfThree(items, 0, len(items)/2, len(items)-1, compare)
+		lt, gt := partitionThreeWay(items, pivot, compare)
+		left, right := items[:lt], items[gt:]
+
+		// Hand the smaller half to the pool and keep looping on the larger one
+		if len(left) > len(right) {
+			left, right = right, left
+		}
+		if len(left) >= parallelCutoff {
+			// Copy depth: the loop keeps decrementing it while the goroutine runs
+			depth := depth
+			pool.run(func() { parallelQuicksort(pool, left, compare, depth) })
+		} else {
+			parallelQuicksort(pool, left, compare, depth)
+		}
+		items = right
+	}
+	InsertionsortFunc(items, compare)
+}
+
+// ParallelMergesort sorts a slice of integers in ascending order using Merge Sort on several goroutines.
+//
+// 🔹 items: slice of integers to be sorted
+//
+// The two halves of each split are sorted concurrently when they are larger than
+// parallelCutoff and a worker is free, then merged on the calling goroutine.
+// Runs below insertionCutoff are sorted with Insertionsort.
+//
+// Time Complexity: O(n log n) work, spread over GOMAXPROCS goroutines
+// Space Complexity: O(n) for the merge buffer
+//
+// ✅ Stable, like Mergesort.
+func ParallelMergesort(items []int) {
+	ParallelMergesortOrdered(items)
+}
+
+// ParallelMergesortOrdered is the generic form of ParallelMergesort for any ordered element type.
+func ParallelMergesortOrdered[T cmp.Ordered](items []T) {
+	ParallelMergesortFunc(items, cmp.Compare[T])
+}
+
+// ParallelMergesortFunc sorts items in ascending order as determined by the compare function.
+//
+// 🔹 items: slice of any type to be sorted
+// 🔹 compare: returns a negative number when a < b, zero when a == b and a positive number when a > b
+func ParallelMergesortFunc[T any](items []T, compare func(a, b T) int) {
+	if len(items) < 2 {
+		return
+	}
+
+	pool := newWorkerPool()
+	parallelMergesort(pool, items, make([]T, len(items)), compare)
+}
+
+// parallelMergesort sorts items using buf (same length) as scratch space.
+// Unlike parallelQuicksort it must wait for both halves before merging,
+// so every call waits on its own WaitGroup rather than on the pool.
+func parallelMergesort[T any](pool *workerPool, items, buf []T, compare func(a, b T) int) {
+	if len(items) <= insertionCutoff {
+		InsertionsortFunc(items, compare)
+		return
+	}
+
+	mid := len(items) / 2
+
+	if len(items) >= 2*parallelCutoff {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		pool.run(func() {
+			defer wg.Done()
+			parallelMergesort(pool, items[:mid], buf[:mid], compare)
+		})
+		parallelMergesort(pool, items[mid:], buf[mid:], compare)
+		wg.Wait()
+	} else {
+		parallelMergesort(pool, items[:mid], buf[:mid], compare)
+		parallelMergesort(pool, items[mid:], buf[mid:], compare)
+	}
+
+	if compare(items[mid-1], items[mid]) <= 0 {
+		return
+	}
+
+	copy(buf, items)
+	merge(items, buf[:mid], buf[mid:], compare)
+}
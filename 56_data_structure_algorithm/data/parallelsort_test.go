@@ -0,0 +1,156 @@
+package data
+
+import (
+	"fmt"
+	"math/bits"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestParallelSorts(t *testing.T) {
+	sorts := map[string]func([]int){
+		"ParallelQuicksort": ParallelQuicksort,
+		"ParallelMergesort": ParallelMergesort,
+	}
+	sizes := append(slices.Clone(testSizes), 100_000, 300_000)
+	for name, sort := range sorts {
+		for _, kind := range inputKinds {
+			for _, n := range sizes {
+				t.Run(fmt.Sprintf("%s/%s/%d", name, kind.name, n), func(t *testing.T) {
+					input := kind.gen(newRand(), n)
+					got := slices.Clone(input)
+					sort(got)
+					checkSorted(t, input, got)
+				})
+			}
+		}
+	}
+}
+
+func TestParallelMergesortStable(t *testing.T) {
+	keys := inputKinds[0].gen(newRand(), 100_000)
+	records := make([]record, len(keys))
+	for i, k := range keys {
+		records[i] = record{key: k % 100, seq: i}
+	}
+
+	ParallelMergesortFunc(records, byKey)
+
+	for i := 1; i < len(records); i++ {
+		prev, cur := records[i-1], records[i]
+		if prev.key > cur.key || prev.key == cur.key && prev.seq > cur.seq {
+			t.Fatalf("index %d: %v after %v", i, cur, prev)
+		}
+	}
+}
+
+// adversary is McIlroy's "killer adversary for quicksort": a comparison function that
+// decides the values of the elements while the sort runs, always so that the pivot
+// ends up near one end. Any quicksort without a fallback takes O(n²) comparisons against it.
+type adversary struct {
+	mu        sync.Mutex // the parallel sorts compare from several goroutines
+	val       []int      // value of each element, gas until it is frozen
+	gas       int        // value of the elements not frozen yet, larger than all frozen ones
+	solid     int        // next value to freeze an element at
+	candidate int        // the element most likely to be the pivot
+	compares  int
+}
+
+func newAdversary(n int) *adversary {
+	a := &adversary{val: make([]int, n), gas: n}
+	for i := range a.val {
+		a.val[i] = a.gas
+	}
+	return a
+}
+
+// compare compares elements by their index into val
+func (a *adversary) compare(x, y int) int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.compares++
+	if a.val[x] == a.gas && a.val[y] == a.gas {
+		if x == a.candidate {
+			a.freeze(x)
+		} else {
+			a.freeze(y)
+		}
+	}
+	if a.val[x] == a.gas {
+		a.candidate = x
+	} else if a.val[y] == a.gas {
+		a.candidate = y
+	}
+	return a.val[x] - a.val[y]
+}
+
+func (a *adversary) freeze(x int) {
+	a.val[x] = a.solid
+	a.solid++
+}
+
+// TestParallelQuicksortAdversary checks that ParallelQuicksort stays O(n log n) on
+// input built to defeat its pivot choice, thanks to the heapsort fallback
+func TestParallelQuicksortAdversary(t *testing.T) {
+	for _, n := range []int{1000, 10_000, 100_000} {
+		t.Run(fmt.Sprint(n), func(t *testing.T) {
+			a := newAdversary(n)
+			ids := make([]int, n)
+			for i := range ids {
+				ids[i] = i
+			}
+
+			ParallelQuicksortFunc(ids, a.compare)
+
+			if !slices.IsSortedFunc(ids, func(x, y int) int { return a.val[x] - a.val[y] }) {
+				t.Fatal("not sorted")
+			}
+			// Heapsort alone needs about 2·n·log2(n) comparisons; quadratic behaviour needs far more
+			if limit := 8 * n * bits.Len(uint(n)); a.compares > limit {
+				t.Fatalf("%d comparisons for %d elements, want at most %d", a.compares, n, limit)
+			}
+			t.Logf("%d comparisons", a.compares)
+		})
+	}
+}
+
+// benchSizes are the slice lengths of the sort benchmarks, 1e3 to 1e7
+var benchSizes = []int{1e3, 1e4, 1e5, 1e6, 1e7}
+
+// benchmarkSort times sort on random input of every size in sizes.
+// Copying the input is part of every iteration, the same for every sort.
+func benchmarkSort(b *testing.B, sizes []int, gen func(r *rand.Rand, n int) []int, sort func([]int)) {
+	for _, n := range sizes {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			input := gen(newRand(), n)
+			items := make([]int, n)
+			for b.Loop() {
+				copy(items, input)
+				sort(items)
+			}
+		})
+	}
+}
+
+// BenchmarkParallelSorts compares the parallel sorts with their sequential versions and slices.Sort:
+//
+//	go test -bench Parallel -benchtime 3x ./56_data_structure_algorithm/data
+func BenchmarkParallelSorts(b *testing.B) {
+	sorts := []struct {
+		name string
+		sort func([]int)
+	}{
+		{"ParallelQuicksort", ParallelQuicksort},
+		{"Quicksort", func(a []int) { Quicksort(a) }},
+		{"ParallelMergesort", ParallelMergesort},
+		{"Mergesort", Mergesort},
+		{"slices.Sort", slices.Sort[[]int]},
+	}
+	for _, s := range sorts {
+		b.Run(s.name, func(b *testing.B) {
+			benchmarkSort(b, benchSizes, inputKinds[0].gen, s.sort)
+		})
+	}
+}
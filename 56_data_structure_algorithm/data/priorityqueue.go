@@ -0,0 +1,115 @@
+package data
+
+import "cmp"
+
+// PQItem is a handle to a value stored in a PriorityQueue.
+// Keep it to change the value's priority with Update or to delete it with Remove.
+type PQItem[V any, P any] struct {
+	Value    V
+	priority P
+	index    int // position in the heap, -1 once the item has left the queue
+}
+
+// Priority returns the current priority of the item.
+func (it *PQItem[V, P]) Priority() P {
+	return it.priority
+}
+
+// PriorityQueue is an indexed min-priority queue: Pop always returns the value with the
+// lowest priority, and every pushed value gets a handle that can later be updated or removed.
+//
+// Time Complexity: Push, Pop, Update and Remove O(log n), Peek O(1)
+//
+// Example, a job scheduler that runs the earliest deadline first:
+//
+//	pq := data.NewPriorityQueue[string, int64]()
+//	job := pq.Push("backup", deadline)
+//	pq.Update(job, sooner) // decrease-key
+//	name, _, _ := pq.Pop()
+type PriorityQueue[V any, P any] struct {
+	heap *Heap[*PQItem[V, P]]
+}
+
+// NewPriorityQueue creates an empty priority queue for an ordered priority type.
+func NewPriorityQueue[V any, P cmp.Ordered]() *PriorityQueue[V, P] {
+	return NewPriorityQueueFunc[V](cmp.Compare[P])
+}
+
+// NewPriorityQueueFunc creates an empty priority queue whose priorities are ordered by compare.
+// Reverse compare to get a max-priority queue.
+func NewPriorityQueueFunc[V any, P any](compare func(a, b P) int) *PriorityQueue[V, P] {
+	return NewDaryPriorityQueueFunc[V](2, compare)
+}
+
+// NewDaryPriorityQueueFunc creates a priority queue backed by a d-ary heap.
+// A larger d (4 or 8 are common) makes Update to a lower priority cheaper,
+// which pays off in decrease-key heavy workloads such as Dijkstra's algorithm.
+func NewDaryPriorityQueueFunc[V any, P any](d int, compare func(a, b P) int) *PriorityQueue[V, P] {
+	h := NewDaryHeapFunc(d, func(a, b *PQItem[V, P]) int {
+		return compare(a.priority, b.priority)
+	})
+	h.moved = func(it *PQItem[V, P], index int) {
+		it.index = index
+	}
+	return &PriorityQueue[V, P]{heap: h}
+}
+
+// Len returns the number of values in the queue.
+func (pq *PriorityQueue[V, P]) Len() int {
+	return pq.heap.Len()
+}
+
+// Push adds value with the given priority and returns its handle.
+func (pq *PriorityQueue[V, P]) Push(value V, priority P) *PQItem[V, P] {
+	it := &PQItem[V, P]{Value: value, priority: priority}
+	pq.heap.Push(it)
+	return it
+}
+
+// Pop removes the value with the lowest priority.
+// ok is false if the queue is empty.
+func (pq *PriorityQueue[V, P]) Pop() (value V, priority P, ok bool) {
+	it, ok := pq.heap.Pop()
+	if !ok {
+		return value, priority, false
+	}
+	it.index = -1
+	return it.Value, it.priority, true
+}
+
+// Peek returns the value with the lowest priority without removing it.
+// ok is false if the queue is empty.
+func (pq *PriorityQueue[V, P]) Peek() (value V, priority P, ok bool) {
+	it, ok := pq.heap.Peek()
+	if !ok {
+		return value, priority, false
+	}
+	return it.Value, it.priority, true
+}
+
+// Update changes the priority of the item and moves it to its new place in the queue.
+// It returns false if the item is no longer in this queue.
+func (pq *PriorityQueue[V, P]) Update(it *PQItem[V, P], priority P) bool {
+	if !pq.contains(it) {
+		return false
+	}
+	it.priority = priority
+	pq.heap.fix(it.index)
+	return true
+}
+
+// Remove deletes the item from the queue.
+// It returns false if the item is no longer in this queue.
+func (pq *PriorityQueue[V, P]) Remove(it *PQItem[V, P]) bool {
+	if !pq.contains(it) {
+		return false
+	}
+	pq.heap.removeAt(it.index)
+	it.index = -1
+	return true
+}
+
+// contains reports whether the handle still points at a live item of this queue.
+func (pq *PriorityQueue[V, P]) contains(it *PQItem[V, P]) bool {
+	return it != nil && it.index >= 0 && it.index < pq.heap.Len() && pq.heap.items[it.index] == it
+}
@@ -1,55 +1,200 @@
 package data
 
 import (
+	"cmp"
+	"math/bits"
 	"math/rand"
 	"time"
 )
 
+// PivotStrategy decides how Quicksort picks the pivot of each partition.
+type PivotStrategy int
+
+const (
+	// PivotNinther uses Tukey's ninther (the median of three medians of three) on large
+	// partitions and the median of three on small ones. It is the default.
+	PivotNinther PivotStrategy = iota
+
+	// PivotMedianOfThree uses the median of the first, middle and last elements.
+	PivotMedianOfThree
+
+	// PivotRandom picks a uniformly random element using QuicksortOptions.Rand.
+	PivotRandom
+)
+
+// nintherThreshold is the partition size from which PivotNinther samples nine elements instead of three.
+const nintherThreshold = 50
+
+// QuicksortOptions configures QuicksortWith.
+// The zero value uses PivotNinther and is fully deterministic.
+type QuicksortOptions struct {
+	Pivot PivotStrategy // how the pivot of each partition is chosen
+
+	// Rand is the random source for PivotRandom. Pass rand.New(rand.NewSource(seed))
+	// to get reproducible runs. If nil, a generator seeded from the clock is created
+	// once per call. It is never shared with the global math/rand state.
+	Rand *rand.Rand
+}
+
 // Quicksort sorts a slice of integers in ascending order using the Quick Sort algorithm.
 //
 // 🔹 a: slice of integers to be sorted
 //
 // Quick Sort is a divide-and-conquer sorting algorithm:
-// 1. Select a pivot element (randomly for better performance on nearly sorted data).
-// 2. Partition the array into elements less than pivot and greater than pivot.
+// 1. Select a pivot element (Tukey's ninther by default, see PivotStrategy).
+// 2. Partition the array into elements less than, equal to and greater than the pivot.
 // 3. Recursively sort the subarrays.
 //
+// Like introsort, it falls back to heapsort when the recursion gets deeper than 2·log2(n),
+// and finishes small partitions with Insertionsort.
+//
 // Time Complexity:
 // - Average case: O(n log n)
-// - Worst case: O(n^2) (rare with random pivot)
+// - Worst case: O(n log n) (thanks to the heapsort fallback)
 // Space Complexity: O(log n) due to recursion
 //
 // ✅ Efficient for large datasets and widely used in practice.
 func Quicksort(a []int) []int {
+	return QuicksortOrdered(a)
+}
+
+// QuicksortOrdered is the generic form of Quicksort for any ordered element type.
+func QuicksortOrdered[T cmp.Ordered](a []T) []T {
+	return QuicksortFunc(a, cmp.Compare[T])
+}
+
+// QuicksortFunc sorts a in ascending order as determined by the compare function
+// and returns the same (now sorted) slice.
+//
+// 🔹 a: slice of any type to be sorted
+// 🔹 compare: returns a negative number when a < b, zero when a == b and a positive number when a > b
+func QuicksortFunc[T any](a []T, compare func(a, b T) int) []T {
+	return QuicksortWith(a, compare, QuicksortOptions{})
+}
+
+// QuicksortWith is QuicksortFunc with a configurable pivot strategy and random source.
+//
+// 🔹 a: slice of any type to be sorted
+// 🔹 compare: returns a negative number when a < b, zero when a == b and a positive number when a > b
+// 🔹 opts: pivot strategy and random source
+//
+// Example, a reproducible random-pivot sort:
+//
+//	data.QuicksortWith(items, cmp.Compare[int], data.QuicksortOptions{
+//		Pivot: data.PivotRandom,
+//		Rand:  rand.New(rand.NewSource(42)),
+//	})
+func QuicksortWith[T any](a []T, compare func(a, b T) int, opts QuicksortOptions) []T {
 	if len(a) < 2 {
 		// Base case: array with 0 or 1 element is already sorted
 		return a
 	}
 
-	// Set a random seed
-	rand.Seed(time.Now().UnixNano())
+	if opts.Pivot == PivotRandom && opts.Rand == nil {
+		// Seed once per sort, not once per partition
+		opts.Rand = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
 
-	// Choose a random pivot index
-	pivot := rand.Intn(len(a))
+	// Allow 2·log2(n) levels of recursion before switching to heapsort
+	depth := 2 * bits.Len(uint(len(a)))
+	introsort(a, compare, &opts, depth)
 
-	// Swap the pivot with the rightmost element
-	left, right := 0, len(a)-1
-	a[pivot], a[right] = a[right], a[pivot]
+	return a
+}
 
-	// Partition the array around the pivot
-	for i := range a[:right] {
-		if a[i] < a[right] {
-			a[left], a[i] = a[i], a[left]
-			left++
+// introsort is the recursive part of QuicksortWith.
+func introsort[T any](a []T, compare func(a, b T) int, opts *QuicksortOptions, depth int) {
+	for len(a) > insertionCutoff {
+		if depth == 0 {
+			// Too many unbalanced partitions: the pivots are bad for this input
+			HeapsortFunc(a, compare)
+			return
+		}
+		depth--
+
+		lt, gt := partitionThreeWay(a, choosePivot(a, compare, opts), compare)
+
+		// Recurse into the smaller side and loop on the larger one to bound the stack at O(log n)
+		if lt < len(a)-gt {
+			introsort(a[:lt], compare, opts, depth)
+			a = a[gt:]
+		} else {
+			introsort(a[gt:], compare, opts, depth)
+			a = a[:lt]
+		}
+	}
+	InsertionsortFunc(a, compare)
+}
+
+// choosePivot returns the index of the pivot for a according to opts.Pivot.
+func choosePivot[T any](a []T, compare func(a, b T) int, opts *QuicksortOptions) int {
+	switch opts.Pivot {
+	case PivotRandom:
+		return opts.Rand.Intn(len(a))
+	case PivotMedianOfThree:
+		return medianOfThree(a, 0, len(a)/2, len(a)-1, compare)
+	default:
+		if len(a) < nintherThreshold {
+			return medianOfThree(a, 0, len(a)/2, len(a)-1, compare)
+		}
+		return ninther(a, compare)
+	}
+}
+
+// medianOfThree returns whichever of the indices i, j, k holds the median value.
+func medianOfThree[T any](a []T, i, j, k int, compare func(a, b T) int) int {
+	if compare(a[j], a[i]) < 0 {
+		i, j = j, i
+	}
+	if compare(a[k], a[j]) < 0 {
+		j = k
+		if compare(a[j], a[i]) < 0 {
+			j = i
 		}
 	}
+	return j
+}
 
-	// Swap the pivot into its correct position
-	a[left], a[right] = a[right], a[left]
+// ninther returns the index of Tukey's ninther: the median of the medians of
+// three evenly spaced groups of three elements.
+func ninther[T any](a []T, compare func(a, b T) int) int {
+	n := len(a)
+	step := n / 8
+	mid := n / 2
+	last := n - 1
 
-	// Recursively sort the subarrays
-	Quicksort(a[:left])
-	Quicksort(a[left+1:])
+	m1 := medianOfThree(a, 0, step, 2*step, compare)
+	m2 := medianOfThree(a, mid-step, mid, mid+step, compare)
+	m3 := medianOfThree(a, last-2*step, last-step, last, compare)
 
-	return a
+	return medianOfThree(a, m1, m2, m3, compare)
+}
+
+// partitionThreeWay partitions a around a[pivot] (Dutch national flag partitioning)
+// and returns lt and gt such that:
+//
+//	a[:lt]   < pivot
+//	a[lt:gt] == pivot
+//	a[gt:]   > pivot
+//
+// Grouping the elements equal to the pivot keeps duplicate-heavy input at O(n log n).
+func partitionThreeWay[T any](a []T, pivot int, compare func(a, b T) int) (lt, gt int) {
+	p := a[pivot]
+
+	lt, i, gt := 0, 0, len(a)
+	for i < gt {
+		switch c := compare(a[i], p); {
+		case c < 0:
+			a[lt], a[i] = a[i], a[lt]
+			lt++
+			i++
+		case c > 0:
+			gt--
+			a[gt], a[i] = a[i], a[gt]
+		default:
+			i++
+		}
+	}
+
+	return lt, gt
 }
@@ -0,0 +1,147 @@
+package data
+
+import (
+	"cmp"
+	"fmt"
+	"math/bits"
+	"math/rand"
+	"slices"
+	"testing"
+	"time"
+)
+
+// pivotStrategies are the strategies the tests and benchmarks run QuicksortWith with
+var pivotStrategies = []struct {
+	name  string
+	pivot PivotStrategy
+}{
+	{"Ninther", PivotNinther},
+	{"MedianOfThree", PivotMedianOfThree},
+	{"Random", PivotRandom},
+}
+
+func TestQuicksortWith(t *testing.T) {
+	for _, s := range pivotStrategies {
+		for _, kind := range inputKinds {
+			for _, n := range testSizes {
+				t.Run(fmt.Sprintf("%s/%s/%d", s.name, kind.name, n), func(t *testing.T) {
+					input := kind.gen(newRand(), n)
+					got := slices.Clone(input)
+					QuicksortWith(got, cmp.Compare[int], QuicksortOptions{Pivot: s.pivot})
+					checkSorted(t, input, got)
+				})
+			}
+		}
+	}
+}
+
+// TestQuicksortSeeded checks that the same seed makes the same comparisons,
+// which is what makes a PivotRandom run reproducible
+func TestQuicksortSeeded(t *testing.T) {
+	input := inputKinds[0].gen(newRand(), 10_000)
+
+	run := func(seed int64) []int {
+		var trace []int
+		compare := func(a, b int) int {
+			trace = append(trace, a, b)
+			return cmp.Compare(a, b)
+		}
+		QuicksortWith(slices.Clone(input), compare, QuicksortOptions{
+			Pivot: PivotRandom,
+			Rand:  rand.New(rand.NewSource(seed)),
+		})
+		return trace
+	}
+
+	if !slices.Equal(run(42), run(42)) {
+		t.Fatal("two runs with seed 42 compared different elements")
+	}
+	if slices.Equal(run(42), run(43)) {
+		t.Fatal("seeds 42 and 43 compared the same elements")
+	}
+}
+
+// TestQuicksortAdversary checks that the heapsort fallback keeps every pivot strategy
+// at O(n log n) comparisons on input built to defeat it
+func TestQuicksortAdversary(t *testing.T) {
+	const n = 100_000
+	for _, s := range pivotStrategies {
+		t.Run(s.name, func(t *testing.T) {
+			a := newAdversary(n)
+			ids := make([]int, n)
+			for i := range ids {
+				ids[i] = i
+			}
+
+			QuicksortWith(ids, a.compare, QuicksortOptions{Pivot: s.pivot, Rand: rand.New(rand.NewSource(1))})
+
+			if !slices.IsSortedFunc(ids, func(x, y int) int { return a.val[x] - a.val[y] }) {
+				t.Fatal("not sorted")
+			}
+			if limit := 8 * n * bits.Len(uint(n)); a.compares > limit {
+				t.Fatalf("%d comparisons for %d elements, want at most %d", a.compares, n, limit)
+			}
+		})
+	}
+}
+
+// legacyQuicksort is Quicksort as it was before QuicksortOptions: a random pivot,
+// a global reseed on every call (a no-op since Go 1.24, but still a clock read) and a two-way
+// partition. It is kept as the benchmark baseline.
+func legacyQuicksort(a []int) {
+	if len(a) < 2 {
+		return
+	}
+
+	rand.Seed(time.Now().UnixNano())
+	pivot := rand.Intn(len(a))
+
+	left, right := 0, len(a)-1
+	a[pivot], a[right] = a[right], a[pivot]
+	for i := range a[:right] {
+		if a[i] < a[right] {
+			a[left], a[i] = a[i], a[left]
+			left++
+		}
+	}
+	a[left], a[right] = a[right], a[left]
+
+	legacyQuicksort(a[:left])
+	legacyQuicksort(a[left+1:])
+}
+
+// quicksortBenchSize is the input length of the pivot strategy benchmarks
+const quicksortBenchSize = 100_000
+
+// benchmarkQuicksort times sort on every input shape in inputKinds
+func benchmarkQuicksort(b *testing.B, sort func([]int)) {
+	for _, kind := range inputKinds {
+		b.Run(kind.name, func(b *testing.B) {
+			benchmarkSort(b, []int{quicksortBenchSize}, kind.gen, sort)
+		})
+	}
+}
+
+// benchmarkPivot times QuicksortWith with the given pivot strategy
+func benchmarkPivot(b *testing.B, pivot PivotStrategy) {
+	opts := QuicksortOptions{Pivot: pivot, Rand: rand.New(rand.NewSource(1))}
+	benchmarkQuicksort(b, func(a []int) { QuicksortWith(a, cmp.Compare[int], opts) })
+}
+
+func BenchmarkQuicksortNinther(b *testing.B) {
+	benchmarkPivot(b, PivotNinther)
+}
+
+func BenchmarkQuicksortMedianOfThree(b *testing.B) {
+	benchmarkPivot(b, PivotMedianOfThree)
+}
+
+func BenchmarkQuicksortRandom(b *testing.B) {
+	benchmarkPivot(b, PivotRandom)
+}
+
+// BenchmarkQuicksortLegacy is the baseline the pivot strategies are measured against.
+// Its two-way partition is quadratic on few-unique input.
+func BenchmarkQuicksortLegacy(b *testing.B) {
+	benchmarkQuicksort(b, legacyQuicksort)
+}
@@ -0,0 +1,160 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/exp/constraints"
+)
+
+// ErrKeyLength is returned by RadixsortBytes when the keys do not all have the same length.
+var ErrKeyLength = errors.New("data: radix sort keys must all have the same length")
+
+// Radixsort sorts a slice of integers in ascending order using LSD (least significant digit) Radix Sort.
+//
+// 🔹 items: slice of integers to be sorted
+//
+// Radix Sort never compares two elements. It looks at one byte of every key at a time,
+// starting with the lowest byte, and distributes the elements into 256 buckets by that byte
+// with a stable counting pass. After the pass over the highest byte the slice is sorted.
+// Passes in which every key has the same byte are skipped.
+//
+// Time Complexity: O(w·n) where w is the number of bytes per key (8 for int)
+// Space Complexity: O(n) for the scatter buffer
+//
+// ✅ Faster than any comparison sort for large slices of integer IDs or timestamps.
+func Radixsort(items []int) {
+	RadixsortIntegers(items)
+}
+
+// RadixsortIntegers is the generic form of Radixsort for any integer type, signed or unsigned.
+func RadixsortIntegers[T constraints.Integer](items []T) {
+	RadixsortFunc(items, integerKey[T])
+}
+
+// RadixsortFunc sorts items in ascending order of key using LSD Radix Sort.
+//
+// 🔹 items: slice of any type to be sorted
+// 🔹 key: maps an element to an unsigned 64-bit key, e.g. a timestamp in nanoseconds
+//
+// The sort is stable.
+func RadixsortFunc[T any](items []T, key func(T) uint64) {
+	n := len(items)
+	if n < 2 {
+		return
+	}
+
+	keys := make([]uint64, n)
+	for i, item := range items {
+		keys[i] = key(item)
+	}
+
+	src, dst := items, make([]T, n)
+	srcKeys, dstKeys := keys, make([]uint64, n)
+
+	for shift := 0; shift < 64; shift += 8 {
+		var counts [256]int
+		for _, k := range srcKeys {
+			counts[byte(k>>shift)]++
+		}
+
+		// Every key has the same byte here: this pass would not move anything
+		if counts[byte(srcKeys[0]>>shift)] == n {
+			continue
+		}
+
+		// Turn the counts into the first output position of every bucket
+		pos := 0
+		for b, c := range counts {
+			counts[b] = pos
+			pos += c
+		}
+
+		// Scatter in input order, which is what keeps the sort stable
+		for i, k := range srcKeys {
+			b := byte(k >> shift)
+			dst[counts[b]] = src[i]
+			dstKeys[counts[b]] = k
+			counts[b]++
+		}
+
+		src, dst = dst, src
+		srcKeys, dstKeys = dstKeys, srcKeys
+	}
+
+	// After an odd number of passes the sorted data lives in the buffer
+	if &src[0] != &items[0] {
+		copy(items, src)
+	}
+}
+
+// RadixsortBytes sorts fixed-length byte keys (e.g. 16-byte UUIDs) in ascending byte order.
+// It returns ErrKeyLength, and leaves items untouched, if the keys differ in length.
+func RadixsortBytes(items [][]byte) error {
+	return RadixsortBytesFunc(items, func(b []byte) []byte { return b })
+}
+
+// RadixsortBytesFunc sorts items by a fixed-length byte key using LSD Radix Sort,
+// one pass per key byte from the last byte to the first.
+//
+// 🔹 items: slice of any type to be sorted
+// 🔹 key: returns the key of an element; all keys must have the same length
+//
+// The sort is stable. Time Complexity: O(k·n) for keys of k bytes.
+func RadixsortBytesFunc[T any](items []T, key func(T) []byte) error {
+	n := len(items)
+	if n < 2 {
+		return nil
+	}
+
+	keys := make([][]byte, n)
+	for i, item := range items {
+		keys[i] = key(item)
+		if len(keys[i]) != len(keys[0]) {
+			return fmt.Errorf("%w: %d and %d bytes", ErrKeyLength, len(keys[0]), len(keys[i]))
+		}
+	}
+
+	src, dst := items, make([]T, n)
+	srcKeys, dstKeys := keys, make([][]byte, n)
+
+	for d := len(keys[0]) - 1; d >= 0; d-- {
+		var counts [256]int
+		for _, k := range srcKeys {
+			counts[k[d]]++
+		}
+		if counts[srcKeys[0][d]] == n {
+			continue
+		}
+
+		pos := 0
+		for b, c := range counts {
+			counts[b] = pos
+			pos += c
+		}
+
+		for i, k := range srcKeys {
+			dst[counts[k[d]]] = src[i]
+			dstKeys[counts[k[d]]] = k
+			counts[k[d]]++
+		}
+
+		src, dst = dst, src
+		srcKeys, dstKeys = dstKeys, srcKeys
+	}
+
+	if &src[0] != &items[0] {
+		copy(items, src)
+	}
+	return nil
+}
+
+// integerKey maps an integer to a uint64 that sorts in the same order.
+// Signed values are sign-extended and get their sign bit flipped, so negative numbers come first.
+func integerKey[T constraints.Integer](v T) uint64 {
+	if ^T(0) < 0 {
+		// Signed type: all bits set is -1
+		return uint64(int64(v)) ^ (1 << 63)
+	}
+	return uint64(v)
+}
@@ -0,0 +1,176 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// integerSorts are the non-comparison sorts for ints
+var integerSorts = []struct {
+	name string
+	sort func([]int)
+}{
+	{"Radixsort", Radixsort},
+	{"Countingsort", Countingsort},
+}
+
+func TestIntegerSorts(t *testing.T) {
+	extremes := []int{math.MaxInt, math.MinInt, 0, -1, 1, math.MinInt + 1, math.MaxInt - 1, -1 << 40, 1 << 40}
+	for _, s := range integerSorts {
+		t.Run(s.name+"/extremes", func(t *testing.T) {
+			got := slices.Clone(extremes)
+			s.sort(got)
+			checkSorted(t, extremes, got)
+		})
+		for _, kind := range inputKinds {
+			for _, n := range testSizes {
+				t.Run(fmt.Sprintf("%s/%s/%d", s.name, kind.name, n), func(t *testing.T) {
+					// Shift half of the values below zero
+					input := kind.gen(newRand(), n)
+					for i := range input {
+						input[i] -= n / 2
+					}
+					got := slices.Clone(input)
+					s.sort(got)
+					checkSorted(t, input, got)
+				})
+			}
+		}
+	}
+}
+
+func TestRadixsortIntegers(t *testing.T) {
+	u := []uint64{math.MaxUint64, 0, 1 << 63, 1<<63 - 1, 42}
+	RadixsortIntegers(u)
+	if !slices.IsSorted(u) {
+		t.Errorf("uint64: %v", u)
+	}
+
+	i8 := []int8{127, -128, 0, -1, 1}
+	RadixsortIntegers(i8)
+	if !slices.IsSorted(i8) {
+		t.Errorf("int8: %v", i8)
+	}
+}
+
+func TestCountingsortIntegers(t *testing.T) {
+	tests := []struct {
+		name string
+		u    []uint64
+	}{
+		{"whole range", []uint64{math.MaxUint64, 0, 1 << 63, 1<<63 - 1, 42, 1<<63 + 1}},
+		// A small span near the top: sorted by counting, not handed to Radixsort
+		{"top of the range", []uint64{math.MaxUint64, math.MaxUint64 - 3, 1<<63 + 2, math.MaxUint64 - 1, 1 << 63, math.MaxUint64}},
+		{"around 2^63", []uint64{1 << 63, 1<<63 - 1, 1<<63 + 1, 1<<63 - 2}},
+	}
+	for _, tt := range tests {
+		got := slices.Clone(tt.u)
+		CountingsortIntegers(got)
+		want := slices.Sorted(slices.Values(tt.u))
+		if !slices.Equal(got, want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, want)
+		}
+	}
+
+	ptrs := []uintptr{^uintptr(0), 0, ^uintptr(0) >> 1, 7}
+	CountingsortIntegers(ptrs)
+	if !slices.IsSorted(ptrs) {
+		t.Errorf("uintptr: %v", ptrs)
+	}
+	i8 := []int8{127, -128, 0, -1, 1}
+	CountingsortIntegers(i8)
+	if !slices.IsSorted(i8) {
+		t.Errorf("int8: %v", i8)
+	}
+}
+
+func TestRadixsortBytes(t *testing.T) {
+	keys := [][]byte{[]byte("cab"), []byte("abc"), []byte("bca"), []byte("abb"), []byte("aaa")}
+	if err := RadixsortBytes(keys); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.IsSortedFunc(keys, func(a, b []byte) int { return slices.Compare(a, b) }) {
+		t.Errorf("not sorted: %q", keys)
+	}
+
+	mixed := [][]byte{[]byte("bb"), []byte("a")}
+	if err := RadixsortBytes(mixed); !errors.Is(err, ErrKeyLength) {
+		t.Errorf("keys of different length: got %v, want ErrKeyLength", err)
+	}
+	if string(mixed[0]) != "bb" {
+		t.Errorf("items were changed after ErrKeyLength: %q", mixed)
+	}
+}
+
+func TestIntegerSortsStable(t *testing.T) {
+	keys := inputKinds[0].gen(newRand(), 10_000)
+	records := make([]record, len(keys))
+	for i, k := range keys {
+		records[i] = record{key: k%100 - 50, seq: i}
+	}
+
+	check := func(t *testing.T, got []record) {
+		for i := 1; i < len(got); i++ {
+			prev, cur := got[i-1], got[i]
+			if prev.key > cur.key || prev.key == cur.key && prev.seq > cur.seq {
+				t.Fatalf("index %d: %v after %v", i, cur, prev)
+			}
+		}
+	}
+
+	t.Run("RadixsortFunc", func(t *testing.T) {
+		got := slices.Clone(records)
+		RadixsortFunc(got, func(r record) uint64 { return integerKey(r.key) })
+		check(t, got)
+	})
+	t.Run("CountingsortFunc", func(t *testing.T) {
+		got := slices.Clone(records)
+		CountingsortFunc(got, func(r record) int { return r.key })
+		check(t, got)
+	})
+}
+
+// BenchmarkIntegerSorts compares the non-comparison sorts with Quicksort and Combsort,
+// on keys from the whole int range and on keys from a small range (e.g. HTTP status codes)
+func BenchmarkIntegerSorts(b *testing.B) {
+	sorts := []struct {
+		name string
+		sort func([]int)
+	}{
+		{"Radixsort", Radixsort},
+		{"Countingsort", Countingsort},
+		{"Bucketsort", bucketsortInts},
+		{"Quicksort", func(a []int) { Quicksort(a) }},
+		{"Combsort", Combsort},
+	}
+	ranges := []struct {
+		name string
+		gen  func(r *rand.Rand, n int) []int
+	}{
+		{"wide", func(r *rand.Rand, n int) []int {
+			a := make([]int, n)
+			for i := range a {
+				a[i] = int(r.Uint64())
+			}
+			return a
+		}},
+		{"small", func(r *rand.Rand, n int) []int {
+			a := make([]int, n)
+			for i := range a {
+				a[i] = 100 + r.IntN(500)
+			}
+			return a
+		}},
+	}
+	for _, rng := range ranges {
+		for _, s := range sorts {
+			b.Run(rng.name+"/"+s.name, func(b *testing.B) {
+				benchmarkSort(b, []int{1e3, 1e5, 1e6}, rng.gen, s.sort)
+			})
+		}
+	}
+}
@@ -1,5 +1,7 @@
 package data
 
+import "cmp"
+
 // Selectionsort sorts a slice of integers in ascending order using the Selection Sort algorithm.
 //
 // 🔹 items: slice of integers to be sorted
@@ -11,6 +13,19 @@ package data
 // Space Complexity: O(1) (in-place sorting)
 // ✅ Simple and intuitive, but not efficient for large datasets.
 func Selectionsort(items []int) {
+	SelectionsortOrdered(items)
+}
+
+// SelectionsortOrdered is the generic form of Selectionsort for any ordered element type.
+func SelectionsortOrdered[T cmp.Ordered](items []T) {
+	SelectionsortFunc(items, cmp.Compare[T])
+}
+
+// SelectionsortFunc sorts items in ascending order as determined by the compare function.
+//
+// 🔹 items: slice of any type to be sorted
+// 🔹 compare: returns a negative number when a < b, zero when a == b and a positive number when a > b
+func SelectionsortFunc[T any](items []T, compare func(a, b T) int) {
 	n := len(items)
 
 	// Iterate over each position in the slice
@@ -19,7 +34,7 @@ func Selectionsort(items []int) {
 
 		// Find the index of the minimum element in the unsorted portion
 		for j := i; j < n; j++ {
-			if items[j] < items[minIdx] {
+			if compare(items[j], items[minIdx]) < 0 {
 				minIdx = j
 			}
 		}
@@ -0,0 +1,118 @@
+package data
+
+import "cmp"
+
+// Timsort sorts a slice of integers in ascending order using a TimSort-style run-merging sort.
+//
+// 🔹 items: slice of integers to be sorted
+//
+// TimSort takes advantage of order that already exists in real-world data:
+// 1. Scan the slice for natural runs (ascending, or strictly descending and then reversed).
+// 2. Extend short runs to a minimum length with insertion sort.
+// 3. Keep the runs on a stack and merge neighbours so their lengths stay balanced.
+//
+// Time Complexity: O(n) best case (already sorted), O(n log n) worst case
+// Space Complexity: O(n) for the merge buffer
+//
+// ✅ Stable and very fast on partially sorted input.
+func Timsort(items []int) {
+	TimsortOrdered(items)
+}
+
+// TimsortOrdered is the generic form of Timsort for any ordered element type.
+func TimsortOrdered[T cmp.Ordered](items []T) {
+	TimsortFunc(items, cmp.Compare[T])
+}
+
+// TimsortFunc sorts items in ascending order as determined by the compare function.
+//
+// 🔹 items: slice of any type to be sorted
+// 🔹 compare: returns a negative number when a < b, zero when a == b and a positive number when a > b
+func TimsortFunc[T any](items []T, compare func(a, b T) int) {
+	n := len(items)
+	if n < 2 {
+		return
+	}
+
+	minRun := timsortMinRun(n)
+	buf := make([]T, n)
+
+	// Each run is stored as [start, start+length)
+	type run struct{ start, length int }
+	var stack []run
+
+	// mergeAt merges the runs at stack[i] and stack[i+1]
+	mergeAt := func(i int) {
+		a, b := stack[i], stack[i+1]
+		lo, mid, hi := a.start, b.start, b.start+b.length
+		copy(buf[lo:hi], items[lo:hi])
+		merge(items[lo:hi], buf[lo:mid], buf[mid:hi], compare)
+		stack[i].length += b.length
+		stack = append(stack[:i+1], stack[i+2:]...)
+	}
+
+	for low := 0; low < n; {
+		// 1️⃣ Find the natural run that starts at low
+		high := low + 1
+		if high < n {
+			if compare(items[high], items[low]) < 0 {
+				// Strictly descending: equal elements are not included so reversing keeps stability
+				for high < n && compare(items[high], items[high-1]) < 0 {
+					high++
+				}
+				reverse(items[low:high])
+			} else {
+				for high < n && compare(items[high], items[high-1]) >= 0 {
+					high++
+				}
+			}
+		}
+
+		// 2️⃣ Extend short runs to minRun with insertion sort
+		if high-low < minRun {
+			high = min(low+minRun, n)
+			InsertionsortFunc(items[low:high], compare)
+		}
+
+		stack = append(stack, run{start: low, length: high - low})
+		low = high
+
+		// 3️⃣ Merge until the run lengths on the stack satisfy the TimSort invariants:
+		//    len[i-2] > len[i-1] + len[i] and len[i-1] > len[i]
+		for len(stack) > 1 {
+			i := len(stack) - 2
+			if i > 0 && stack[i-1].length <= stack[i].length+stack[i+1].length ||
+				i > 1 && stack[i-2].length <= stack[i-1].length+stack[i].length {
+				if stack[i-1].length < stack[i+1].length {
+					i--
+				}
+			} else if stack[i].length > stack[i+1].length {
+				break
+			}
+			mergeAt(i)
+		}
+	}
+
+	// Merge all remaining runs, right to left
+	for len(stack) > 1 {
+		mergeAt(len(stack) - 2)
+	}
+}
+
+// timsortMinRun picks a run length between 16 and 32 so that n/minRun is
+// close to (but not more than) a power of two, which keeps the final merges balanced.
+func timsortMinRun(n int) int {
+	r := 0
+	for n >= 32 {
+		r |= n & 1
+		n >>= 1
+	}
+	return n + r
+}
+
+// reverse reverses items in place.
+func reverse[T any](items []T) {
+	for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+		items[i], items[j] = items[j], items[i]
+	}
+}
@@ -0,0 +1,275 @@
+package data
+
+import (
+	"cmp"
+	"iter"
+	"math"
+	"strings"
+)
+
+// Completion is one result of Trie.TopK.
+type Completion[V any] struct {
+	Key    string  `json:"key"`
+	Value  V       `json:"value"`
+	Weight float64 `json:"weight"`
+}
+
+// Trie is a prefix tree that maps string keys to values, built for autocomplete.
+//
+// Every node stands for one prefix and has one child per possible next character,
+// so all keys that share a prefix share the path from the root to that prefix's node.
+// Children are kept sorted by rune, which makes PrefixIter return keys in lexicographic order.
+//
+// Each key also carries a weight (popularity, number of orders, ...).
+// Every node remembers the largest weight below it, which lets TopK skip whole subtrees.
+//
+// Time Complexity: Insert, Get, Delete, LongestPrefix O(m log σ) for a key of m runes and σ distinct runes per node
+// Space Complexity: O(total number of runes in all keys)
+//
+// The zero value is an empty trie ready to use.
+type Trie[V any] struct {
+	root trieNode[V]
+	len  int
+}
+
+// trieNode is one prefix in the trie.
+type trieNode[V any] struct {
+	children  []trieEdge[V] // Sorted by r
+	value     V
+	weight    float64
+	terminal  bool    // True if a key ends at this node
+	maxWeight float64 // Largest weight of any key in this subtree
+}
+
+// trieEdge links a node to the child reached by reading rune r.
+type trieEdge[V any] struct {
+	r    rune
+	node *trieNode[V]
+}
+
+// Len returns the number of keys in the trie.
+func (t *Trie[V]) Len() int {
+	return t.len
+}
+
+// Insert stores value and weight for key, replacing any previous entry.
+func (t *Trie[V]) Insert(key string, value V, weight float64) {
+	path := []*trieNode[V]{&t.root}
+	n := &t.root
+
+	// Walk down the key, creating missing nodes on the way
+	for _, r := range key {
+		i, found := n.find(r)
+		if !found {
+			child := &trieNode[V]{maxWeight: math.Inf(-1)}
+			n.children = append(n.children, trieEdge[V]{})
+			copy(n.children[i+1:], n.children[i:])
+			n.children[i] = trieEdge[V]{r: r, node: child}
+		}
+		n = n.children[i].node
+		path = append(path, n)
+	}
+
+	if !n.terminal {
+		t.len++
+	}
+	n.value, n.weight, n.terminal = value, weight, true
+	updateMaxWeights(path)
+}
+
+// Get returns the value stored for key; ok is false if the key is not present.
+func (t *Trie[V]) Get(key string) (value V, ok bool) {
+	n := t.node(key)
+	if n == nil || !n.terminal {
+		return value, false
+	}
+	return n.value, true
+}
+
+// Delete removes key from the trie and reports whether it was present.
+// Nodes that no longer lead to any key are pruned.
+func (t *Trie[V]) Delete(key string) bool {
+	path := []*trieNode[V]{&t.root}
+	var runes []rune
+	n := &t.root
+
+	for _, r := range key {
+		i, found := n.find(r)
+		if !found {
+			return false
+		}
+		n = n.children[i].node
+		path = append(path, n)
+		runes = append(runes, r)
+	}
+	if !n.terminal {
+		return false
+	}
+
+	var zero V
+	n.value, n.weight, n.terminal = zero, 0, false
+	t.len--
+
+	// Prune childless non-terminal nodes from the bottom up
+	for d := len(path) - 1; d > 0; d-- {
+		child := path[d]
+		if child.terminal || len(child.children) > 0 {
+			break
+		}
+		parent := path[d-1]
+		i, _ := parent.find(runes[d-1])
+		parent.children = append(parent.children[:i], parent.children[i+1:]...)
+		path = path[:d]
+	}
+
+	updateMaxWeights(path)
+	return true
+}
+
+// LongestPrefix returns the longest key in the trie that is a prefix of s.
+// For example, with keys "go" and "golang", LongestPrefix("golang.org") returns "golang".
+// ok is false if no key is a prefix of s.
+func (t *Trie[V]) LongestPrefix(s string) (key string, value V, ok bool) {
+	n := &t.root
+	if n.terminal {
+		value, ok = n.value, true
+	}
+
+	for pos, r := range s {
+		i, found := n.find(r)
+		if !found {
+			break
+		}
+		n = n.children[i].node
+		if n.terminal {
+			key, value, ok = s[:pos+len(string(r))], n.value, true
+		}
+	}
+	return key, value, ok
+}
+
+// PrefixIter returns an iterator over all keys starting with prefix, and their values,
+// in lexicographic order:
+//
+//	for name, customer := range names.PrefixIter("jo") { ... }
+func (t *Trie[V]) PrefixIter(prefix string) iter.Seq2[string, V] {
+	return func(yield func(string, V) bool) {
+		n := t.node(prefix)
+		if n == nil {
+			return
+		}
+		var sb strings.Builder
+		sb.WriteString(prefix)
+		n.walk(&sb, yield)
+	}
+}
+
+// TopK returns up to k keys starting with prefix, heaviest first.
+// Keys with the same weight are returned in lexicographic order.
+//
+// It runs a best-first search with a Heap: subtrees are expanded in order of the largest
+// weight they contain, so only the branches that can still produce a top result are visited.
+func (t *Trie[V]) TopK(prefix string, k int) []Completion[V] {
+	start := t.node(prefix)
+	if start == nil || k <= 0 {
+		return nil
+	}
+
+	// A candidate is either a finished key (node == nil) or a subtree still to expand
+	type candidate struct {
+		key    string
+		weight float64
+		node   *trieNode[V]
+		value  V
+	}
+	h := NewHeapFunc(func(a, b candidate) int {
+		// Heaviest first, then by key; a finished key beats a subtree with the same bound
+		if c := cmp.Compare(b.weight, a.weight); c != 0 {
+			return c
+		}
+		if c := strings.Compare(a.key, b.key); c != 0 {
+			return c
+		}
+		return cmp.Compare(boolToInt(a.node != nil), boolToInt(b.node != nil))
+	})
+	h.Push(candidate{key: prefix, weight: start.maxWeight, node: start})
+
+	var out []Completion[V]
+	for len(out) < k {
+		c, ok := h.Pop()
+		if !ok {
+			break
+		}
+		if c.node == nil {
+			out = append(out, Completion[V]{Key: c.key, Value: c.value, Weight: c.weight})
+			continue
+		}
+
+		if c.node.terminal {
+			h.Push(candidate{key: c.key, weight: c.node.weight, value: c.node.value})
+		}
+		for _, e := range c.node.children {
+			h.Push(candidate{key: c.key + string(e.r), weight: e.node.maxWeight, node: e.node})
+		}
+	}
+	return out
+}
+
+// node returns the node for prefix, or nil if no key starts with prefix.
+func (t *Trie[V]) node(prefix string) *trieNode[V] {
+	n := &t.root
+	for _, r := range prefix {
+		i, found := n.find(r)
+		if !found {
+			return nil
+		}
+		n = n.children[i].node
+	}
+	return n
+}
+
+// find returns the index of the child for r, or the index where it would be inserted.
+func (n *trieNode[V]) find(r rune) (int, bool) {
+	i := LowerBoundFunc(n.children, r, func(e trieEdge[V], r rune) int {
+		return cmp.Compare(e.r, r)
+	})
+	return i, i < len(n.children) && n.children[i].r == r
+}
+
+// walk yields every key in the subtree in lexicographic order; sb holds the current prefix.
+func (n *trieNode[V]) walk(sb *strings.Builder, yield func(string, V) bool) bool {
+	if n.terminal && !yield(sb.String(), n.value) {
+		return false
+	}
+	for _, e := range n.children {
+		prefix := sb.String()
+		sb.WriteRune(e.r)
+		if !e.node.walk(sb, yield) {
+			return false
+		}
+		sb.Reset()
+		sb.WriteString(prefix)
+	}
+	return true
+}
+
+// updateMaxWeights recomputes maxWeight for every node on a root-to-node path, bottom up.
+func updateMaxWeights[V any](path []*trieNode[V]) {
+	for i := len(path) - 1; i >= 0; i-- {
+		n := path[i]
+		n.maxWeight = math.Inf(-1)
+		if n.terminal {
+			n.maxWeight = n.weight
+		}
+		for _, e := range n.children {
+			n.maxWeight = max(n.maxWeight, e.node.maxWeight)
+		}
+	}
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
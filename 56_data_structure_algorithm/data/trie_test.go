@@ -0,0 +1,243 @@
+package data
+
+import (
+	"cmp"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// checkTrie fails the test if a node of the subtree of n is a dead end (no key at it or below),
+// has unsorted children or remembers the wrong largest weight
+func checkTrie[V any](t *testing.T, n *trieNode[V], root bool) {
+	t.Helper()
+	if !root && !n.terminal && len(n.children) == 0 {
+		t.Fatal("node leads to no key; it should have been pruned")
+	}
+	want := math.Inf(-1)
+	if n.terminal {
+		want = n.weight
+	}
+	for i, e := range n.children {
+		if i > 0 && n.children[i-1].r >= e.r {
+			t.Fatalf("children %q and %q out of order", n.children[i-1].r, e.r)
+		}
+		checkTrie(t, e.node, false)
+		want = max(want, e.node.maxWeight)
+	}
+	if n.maxWeight != want && (len(n.children) > 0 || n.terminal) {
+		t.Fatalf("maxWeight %g, want %g", n.maxWeight, want)
+	}
+}
+
+// topK is what Trie.TopK must return, computed from a map of keys to weights
+func topK(weights map[string]float64, prefix string, k int) []string {
+	var keys []string
+	for key := range weights {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	slices.SortFunc(keys, func(a, b string) int {
+		if c := cmp.Compare(weights[b], weights[a]); c != 0 {
+			return c
+		}
+		return strings.Compare(a, b)
+	})
+	return keys[:min(k, len(keys))]
+}
+
+func TestTrie(t *testing.T) {
+	r := newRand()
+	var trie Trie[int]
+	weights := make(map[string]float64)
+	values := make(map[string]int)
+
+	// Short keys over a small alphabet share many prefixes; é and 世 are multi-byte
+	alphabet := []rune("abcé世")
+	randomKey := func() string {
+		k := make([]rune, r.IntN(5))
+		for i := range k {
+			k[i] = alphabet[r.IntN(len(alphabet))]
+		}
+		return string(k)
+	}
+
+	for step := range 4000 {
+		key := randomKey()
+		if r.IntN(3) == 0 {
+			_, present := values[key]
+			if got := trie.Delete(key); got != present {
+				t.Fatalf("step %d: Delete(%q) = %v, want %v", step, key, got, present)
+			}
+			delete(values, key)
+			delete(weights, key)
+		} else {
+			w := float64(r.IntN(50))
+			trie.Insert(key, step, w)
+			values[key], weights[key] = step, w
+		}
+		if trie.Len() != len(values) {
+			t.Fatalf("step %d: Len = %d, want %d", step, trie.Len(), len(values))
+		}
+		checkTrie(t, &trie.root, true)
+		if v, ok := trie.Get(key); ok != hasKey(values, key) || v != values[key] {
+			t.Fatalf("step %d: Get(%q) = %d, %v; want %d", step, key, v, ok, values[key])
+		}
+
+		if step%200 == 0 {
+			for _, prefix := range []string{"", "a", "é", "b世", "cc"} {
+				var got []string
+				for k, v := range trie.PrefixIter(prefix) {
+					if v != values[k] {
+						t.Fatalf("PrefixIter(%q) yields %q = %d, want %d", prefix, k, v, values[k])
+					}
+					got = append(got, k)
+				}
+				var want []string
+				for _, k := range slices.Sorted(maps.Keys(values)) {
+					if strings.HasPrefix(k, prefix) {
+						want = append(want, k)
+					}
+				}
+				if !slices.Equal(got, want) {
+					t.Fatalf("step %d: PrefixIter(%q) = %q, want %q", step, prefix, got, want)
+				}
+
+				for _, k := range []int{1, 3, 10} {
+					var keys []string
+					for _, c := range trie.TopK(prefix, k) {
+						keys = append(keys, c.Key)
+						if c.Weight != weights[c.Key] || c.Value != values[c.Key] {
+							t.Fatalf("TopK(%q) returned %+v, want weight %g, value %d", prefix, c, weights[c.Key], values[c.Key])
+						}
+					}
+					if want := topK(weights, prefix, k); !slices.Equal(keys, want) {
+						t.Fatalf("step %d: TopK(%q, %d) = %q, want %q", step, prefix, k, keys, want)
+					}
+				}
+			}
+		}
+	}
+
+	// Deleting every key prunes the whole tree
+	for key := range values {
+		if !trie.Delete(key) {
+			t.Fatalf("Delete(%q) = false", key)
+		}
+	}
+	if trie.Len() != 0 || len(trie.root.children) != 0 || trie.root.terminal {
+		t.Fatalf("trie not empty after deleting every key: %d keys, %d children", trie.Len(), len(trie.root.children))
+	}
+}
+
+func hasKey(m map[string]int, key string) bool {
+	_, ok := m[key]
+	return ok
+}
+
+func TestTriePrune(t *testing.T) {
+	var trie Trie[string]
+	trie.Insert("go", "go", 1)
+	trie.Insert("golang", "golang", 2)
+	trie.Insert("gopher", "gopher", 3)
+
+	// Deleting an inner key keeps the longer ones
+	if !trie.Delete("go") || trie.Delete("go") || trie.Delete("gol") || trie.Delete("gophers") {
+		t.Fatal("Delete reported the wrong result")
+	}
+	if _, ok := trie.Get("go"); ok {
+		t.Fatal("go is still there")
+	}
+	if v, ok := trie.Get("golang"); !ok || v != "golang" {
+		t.Fatalf("Get(golang) = %q, %v", v, ok)
+	}
+
+	// Deleting a leaf removes its whole branch up to the shared prefix
+	trie.Delete("golang")
+	o := trie.node("go")
+	if o == nil || len(o.children) != 1 || o.children[0].r != 'p' {
+		t.Fatalf("the branch of golang was not pruned: %+v", o)
+	}
+	if trie.node("gol") != nil {
+		t.Fatal("node gol still exists")
+	}
+	checkTrie(t, &trie.root, true)
+	if c := trie.TopK("", 5); len(c) != 1 || c[0].Key != "gopher" {
+		t.Fatalf("TopK = %+v, want only gopher", c)
+	}
+}
+
+func TestTrieLongestPrefix(t *testing.T) {
+	var trie Trie[int]
+	for i, k := range []string{"go", "golang", "golang.org/x", "世界"} {
+		trie.Insert(k, i, 0)
+	}
+	tests := []struct {
+		s, key string
+		ok     bool
+	}{
+		{"golang.org/x/exp", "golang.org/x", true},
+		{"golang.org", "golang", true},
+		{"gola", "go", true},
+		{"go", "go", true},
+		{"g", "", false},
+		{"", "", false},
+		{"rust", "", false},
+		{"世界你好", "世界", true},
+		{"世", "", false},
+	}
+	for _, tt := range tests {
+		key, _, ok := trie.LongestPrefix(tt.s)
+		if key != tt.key || ok != tt.ok {
+			t.Errorf("LongestPrefix(%q) = %q, %v; want %q, %v", tt.s, key, ok, tt.key, tt.ok)
+		}
+	}
+
+	// The empty key is a prefix of everything
+	trie.Insert("", -1, 0)
+	if key, v, ok := trie.LongestPrefix("rust"); key != "" || v != -1 || !ok {
+		t.Errorf("LongestPrefix(rust) with the empty key = %q, %d, %v", key, v, ok)
+	}
+}
+
+func TestTrieTopK(t *testing.T) {
+	var trie Trie[string]
+	for _, c := range []Completion[string]{
+		{"pizza", "🍕", 50}, {"pasta", "🍝", 80}, {"pancakes", "🥞", 80},
+		{"pie", "🥧", 10}, {"soup", "🍲", 90}, {"p", "?", 5},
+	} {
+		trie.Insert(c.Key, c.Value, c.Weight)
+	}
+
+	tests := []struct {
+		prefix string
+		k      int
+		want   []string
+	}{
+		{"p", 3, []string{"pancakes", "pasta", "pizza"}}, // equal weights in key order
+		{"p", 10, []string{"pancakes", "pasta", "pizza", "pie", "p"}},
+		{"", 2, []string{"soup", "pancakes"}},
+		{"pi", 1, []string{"pizza"}},
+		{"x", 3, nil},
+		{"p", 0, nil},
+	}
+	for _, tt := range tests {
+		var keys []string
+		for _, c := range trie.TopK(tt.prefix, tt.k) {
+			keys = append(keys, c.Key)
+		}
+		if !slices.Equal(keys, tt.want) {
+			t.Errorf("TopK(%q, %d) = %q, want %q", tt.prefix, tt.k, keys, tt.want)
+		}
+	}
+
+	// Re-inserting changes the weight, deleting the heaviest key lets the next one through
+	trie.Insert("pie", "🥧", 100)
+	trie.Delete("soup")
+	if c := trie.TopK("", 1); len(c) != 1 || c[0].Key != "pie" || c[0].Value != "🥧" || c[0].Weight != 100 {
+		t.Fatalf("TopK after the update = %+v", c)
+	}
+}
@@ -0,0 +1,198 @@
+package data
+
+import (
+	"sync"
+	"time"
+)
+
+// TTLCache is a fixed-size cache whose entries expire after a time to live (TTL).
+//
+// Expired entries are never returned: Get checks the deadline itself.
+// A background janitor goroutine also sweeps out expired entries regularly,
+// so values nobody asks for again do not stay in memory until they are evicted.
+// When the cache is full, the least recently used entry is evicted, exactly like LRUCache.
+//
+// Time Complexity: Get, Peek, Put, Delete O(1); each janitor sweep O(n)
+// Space Complexity: O(capacity)
+//
+// ✅ Safe for concurrent use by multiple goroutines.
+// ⚠️ Call Close when the cache is no longer needed, otherwise the janitor goroutine keeps running.
+type TTLCache[K comparable, V any] struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	order    List[K] // Front = most recently used
+	items    map[K]*ttlEntry[K, V]
+	onEvict  EvictionFunc[K, V]
+	stats    CacheStats
+
+	stop      chan struct{} // Closed by Close to stop the janitor
+	closeOnce sync.Once
+}
+
+type ttlEntry[K comparable, V any] struct {
+	value     V
+	expiresAt time.Time
+	node      *Node[K] // The key's node in order
+}
+
+// NewTTLCache creates an empty cache and starts its janitor.
+// The janitor sweeps every ttl/2, so an entry stays in memory at most 1.5×ttl.
+//
+// 🔹 capacity: maximum number of entries (at least 1)
+// 🔹 ttl: default time to live for Put (at least 1ms)
+// 🔹 onEvict: called for each entry evicted to make room or because it expired; may be nil
+func NewTTLCache[K comparable, V any](capacity int, ttl time.Duration, onEvict EvictionFunc[K, V]) *TTLCache[K, V] {
+	capacity = max(capacity, 1)
+	ttl = max(ttl, time.Millisecond)
+	c := &TTLCache[K, V]{
+		capacity: capacity,
+		ttl:      ttl,
+		items:    make(map[K]*ttlEntry[K, V], capacity),
+		onEvict:  onEvict,
+		stop:     make(chan struct{}),
+	}
+	go c.janitor(ttl / 2)
+	return c
+}
+
+// janitor removes expired entries every interval until Close is called.
+func (c *TTLCache[K, V]) janitor(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			c.removeExpired(now)
+		case <-c.stop:
+			return
+		}
+	}
+}
+
+// removeExpired drops every entry whose deadline is not after now.
+func (c *TTLCache[K, V]) removeExpired(now time.Time) {
+	var out []evicted[K, V]
+
+	c.mu.Lock()
+	for key, e := range c.items {
+		if !now.Before(e.expiresAt) {
+			out = append(out, c.remove(key, e, EvictedExpired))
+		}
+	}
+	c.mu.Unlock()
+
+	notifyEvicted(c.onEvict, out)
+}
+
+// remove deletes an entry that is being evicted and counts it.
+func (c *TTLCache[K, V]) remove(key K, e *ttlEntry[K, V], reason EvictionReason) evicted[K, V] {
+	c.order.RemoveNode(e.node)
+	delete(c.items, key)
+	c.stats.Evictions++
+	return evicted[K, V]{key: key, value: e.value, reason: reason}
+}
+
+// Get returns the value for key and marks it as most recently used.
+// ok is false if it is not cached or has expired; an expired entry is evicted right away.
+func (c *TTLCache[K, V]) Get(key K) (value V, ok bool) {
+	var out []evicted[K, V]
+
+	c.mu.Lock()
+	e, ok := c.items[key]
+	switch {
+	case !ok:
+		c.stats.Misses++
+	case !time.Now().Before(e.expiresAt):
+		c.stats.Misses++
+		out = append(out, c.remove(key, e, EvictedExpired))
+		ok = false
+	default:
+		c.stats.Hits++
+		c.order.MoveToFront(e.node)
+		value = e.value
+	}
+	c.mu.Unlock()
+
+	notifyEvicted(c.onEvict, out)
+	return value, ok
+}
+
+// Peek returns the value for key if it has not expired, without changing its position or the stats.
+func (c *TTLCache[K, V]) Peek(key K) (value V, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.items[key]; ok && time.Now().Before(e.expiresAt) {
+		return e.value, true
+	}
+	return value, false
+}
+
+// Put stores value for key with the cache's default TTL.
+func (c *TTLCache[K, V]) Put(key K, value V) {
+	c.PutWithTTL(key, value, c.ttl)
+}
+
+// PutWithTTL stores value for key, expiring after ttl, and marks it as most recently used.
+// If the cache is full, the least recently used entry is evicted first.
+func (c *TTLCache[K, V]) PutWithTTL(key K, value V, ttl time.Duration) {
+	var out []evicted[K, V]
+	expiresAt := time.Now().Add(ttl)
+
+	c.mu.Lock()
+	if e, ok := c.items[key]; ok {
+		e.value = value
+		e.expiresAt = expiresAt
+		c.order.MoveToFront(e.node)
+	} else {
+		if len(c.items) >= c.capacity {
+			oldest := c.order.Back().Value
+			reason := EvictedCapacity
+			if old := c.items[oldest]; !time.Now().Before(old.expiresAt) {
+				// It had already expired, the janitor just had not got to it yet
+				reason = EvictedExpired
+			}
+			out = append(out, c.remove(oldest, c.items[oldest], reason))
+		}
+		c.items[key] = &ttlEntry[K, V]{value: value, expiresAt: expiresAt, node: c.order.PushFront(key)}
+	}
+	c.mu.Unlock()
+
+	notifyEvicted(c.onEvict, out)
+}
+
+// Delete removes key and reports whether it was cached. The EvictionFunc is not called.
+func (c *TTLCache[K, V]) Delete(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.items[key]
+	if !ok {
+		return false
+	}
+	c.order.RemoveNode(e.node)
+	delete(c.items, key)
+	return true
+}
+
+// Len returns the number of cached entries, including expired ones the janitor has not removed yet.
+func (c *TTLCache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.items)
+}
+
+// Stats returns a snapshot of the hit, miss and eviction counters.
+func (c *TTLCache[K, V]) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+// Close stops the janitor goroutine. The cache keeps working, but expired entries
+// are then only removed when Get finds them. Calling Close more than once is safe.
+func (c *TTLCache[K, V]) Close() {
+	c.closeOnce.Do(func() { close(c.stop) })
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+
+	"master_go_programming/56_data_structure_algorithm/data"
+	"master_go_programming/56_data_structure_algorithm/helper"
+)
+
+// runDemo walks through the searches, the sorts and the linked list one by one
+func runDemo() {
+
+	//Linearsearch
+	items := []int{95, 78, 46, 58, 45, 86, 99, 251, 320}
+	fmt.Println("LinearSearch 78:", data.LinearSearch(items, 78))
+
+	//Binarysearch
+	items = []int{1, 2, 9, 20, 31, 45, 63, 70, 100}
+	fmt.Println("BinarySearch 63:", data.BinarySearch(items, 63))
+
+	//Interpolationsearch
+	fmt.Println("InterpolationSearch 63:")
+	fmt.Println(data.InterpolationSearch(items, 63))
+
+	sorts := []struct {
+		name string
+		sort func([]int)
+	}{
+		{"Bubblesort", data.Bubblesort},
+		{"Quicksort", func(s []int) { data.Quicksort(s) }},
+		{"Selectionsort", data.Selectionsort},
+		{"Combsort", data.Combsort},
+		{"Insertionsort", data.Insertionsort},
+	}
+	for _, s := range sorts {
+		slice := helper.GenerateSlice(20)
+		fmt.Println("\nThis is", s.name)
+		fmt.Println("\n--- Unsorted --- \n\n", slice)
+		s.sort(slice)
+		fmt.Println("\n--- Sorted ---\n\n", slice)
+	}
+
+	list := &data.List[int]{}
+	list.Add(1)
+	list.Add(2)
+	list.Add(3)
+	list.Add(4)
+
+	fmt.Println("\nInitial List: ")
+	data.PrintList(list)
+
+	data.Remove(list, 2)
+	fmt.Println("List afteR Removing 2: ")
+	data.PrintList(list)
+
+	data.Remove(list, 4)
+	fmt.Println("List afteR Removing 4: ")
+	data.PrintList(list)
+}
@@ -0,0 +1,48 @@
+package graph
+
+// ConnectedComponents groups the vertices into connected components.
+// In a directed graph edge directions are ignored (weakly connected components).
+//
+// Components are returned in order of their first vertex, and the vertices of each
+// component in BFS order from that vertex.
+//
+// Time Complexity: O(V + E)
+// Space Complexity: O(V + E) (O(V) for undirected graphs)
+func (g *Graph[V]) ConnectedComponents() [][]V {
+	neighbours := g.adj
+
+	// In a directed graph also follow edges backwards
+	if g.directed {
+		neighbours = make(map[V][]Edge[V], g.Len())
+		for _, v := range g.order {
+			for _, e := range g.adj[v] {
+				neighbours[e.From] = append(neighbours[e.From], e)
+				neighbours[e.To] = append(neighbours[e.To], Edge[V]{From: e.To, To: e.From, Weight: e.Weight})
+			}
+		}
+	}
+
+	visited := make(map[V]bool, g.Len())
+	var components [][]V
+
+	for _, start := range g.order {
+		if visited[start] {
+			continue
+		}
+
+		// BFS from start collects one component
+		visited[start] = true
+		component := []V{start}
+		for i := 0; i < len(component); i++ {
+			for _, e := range neighbours[component[i]] {
+				if !visited[e.To] {
+					visited[e.To] = true
+					component = append(component, e.To)
+				}
+			}
+		}
+		components = append(components, component)
+	}
+
+	return components
+}
@@ -0,0 +1,31 @@
+package graph
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestConnectedComponents(t *testing.T) {
+	tests := []struct {
+		name string
+		g    *Graph[string]
+		want [][]string
+	}{
+		{"empty", build(true, nil), nil},
+		{"undirected", build(false, []string{"x"}, edge{"a", "b", 1}, edge{"c", "d", 1}, edge{"b", "e", 1}, edge{"d", "c", 1}),
+			[][]string{{"x"}, {"a", "b", "e"}, {"c", "d"}}},
+		// Directions are ignored: c only has edges into it, yet it joins a and b
+		{"directed", build(true, nil, edge{"a", "c", 1}, edge{"b", "c", 1}, edge{"d", "e", 1}, edge{"f", "f", 1}),
+			[][]string{{"a", "c", "b"}, {"d", "e"}, {"f"}}},
+		{"directed chain entered from the end", build(true, []string{"z"}, edge{"y", "z", 1}, edge{"x", "y", 1}),
+			[][]string{{"z", "y", "x"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.g.ConnectedComponents()
+			if !slices.EqualFunc(got, tt.want, slices.Equal) {
+				t.Errorf("ConnectedComponents = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
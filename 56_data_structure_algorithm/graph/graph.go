@@ -0,0 +1,99 @@
+// Package graph provides weighted directed and undirected graphs stored as adjacency lists,
+// together with the classic graph algorithms: BFS, DFS, Dijkstra, Bellman-Ford,
+// topological sort and connected components.
+package graph
+
+import "errors"
+
+var (
+	// ErrVertexNotFound is returned when an algorithm is started from a vertex that is not in the graph.
+	ErrVertexNotFound = errors.New("graph: vertex not found")
+
+	// ErrNegativeWeight is returned by Dijkstra when the graph has an edge with a negative weight.
+	ErrNegativeWeight = errors.New("graph: negative edge weight")
+
+	// ErrNegativeCycle is returned by BellmanFord when a negative cycle is reachable from the source.
+	ErrNegativeCycle = errors.New("graph: negative cycle")
+
+	// ErrUndirected is returned by TopologicalSort on an undirected graph.
+	ErrUndirected = errors.New("graph: topological sort needs a directed graph")
+)
+
+// Edge is a weighted connection from one vertex to another.
+type Edge[V comparable] struct {
+	From   V
+	To     V
+	Weight float64
+}
+
+// Graph is a weighted graph stored as an adjacency list:
+// every vertex maps to the list of edges that leave it.
+//
+// 🔹 V: the vertex type, e.g. a build step name or a delivery stop ID
+//
+// Space Complexity: O(V + E)
+//
+// Vertices are remembered in insertion order, so every algorithm visits them
+// in a predictable order and gives the same answer on every run.
+type Graph[V comparable] struct {
+	directed bool
+	adj      map[V][]Edge[V] // outgoing edges of each vertex
+	order    []V             // vertices in insertion order
+}
+
+// NewDirected creates an empty directed graph: AddEdge(a, b, w) only connects a to b.
+func NewDirected[V comparable]() *Graph[V] {
+	return &Graph[V]{directed: true, adj: make(map[V][]Edge[V])}
+}
+
+// NewUndirected creates an empty undirected graph: AddEdge(a, b, w) connects both ways.
+func NewUndirected[V comparable]() *Graph[V] {
+	return &Graph[V]{directed: false, adj: make(map[V][]Edge[V])}
+}
+
+// Directed reports whether the graph is directed.
+func (g *Graph[V]) Directed() bool {
+	return g.directed
+}
+
+// AddVertex adds v to the graph. Adding an existing vertex does nothing.
+func (g *Graph[V]) AddVertex(v V) {
+	if _, ok := g.adj[v]; ok {
+		return
+	}
+	g.adj[v] = nil
+	g.order = append(g.order, v)
+}
+
+// AddEdge adds an edge from -> to with the given weight, adding missing vertices on the way.
+// In an undirected graph the reverse edge is added too.
+func (g *Graph[V]) AddEdge(from, to V, weight float64) {
+	g.AddVertex(from)
+	g.AddVertex(to)
+
+	g.adj[from] = append(g.adj[from], Edge[V]{From: from, To: to, Weight: weight})
+	if !g.directed && from != to {
+		g.adj[to] = append(g.adj[to], Edge[V]{From: to, To: from, Weight: weight})
+	}
+}
+
+// HasVertex reports whether v is in the graph.
+func (g *Graph[V]) HasVertex(v V) bool {
+	_, ok := g.adj[v]
+	return ok
+}
+
+// Vertices returns all vertices in insertion order.
+func (g *Graph[V]) Vertices() []V {
+	return append([]V(nil), g.order...)
+}
+
+// Edges returns the edges leaving v.
+func (g *Graph[V]) Edges(v V) []Edge[V] {
+	return append([]Edge[V](nil), g.adj[v]...)
+}
+
+// Len returns the number of vertices.
+func (g *Graph[V]) Len() int {
+	return len(g.order)
+}
@@ -0,0 +1,51 @@
+package graph
+
+import (
+	"slices"
+	"testing"
+)
+
+// edge is an edge of a test graph; the weight is 1 unless given
+type edge struct {
+	from, to string
+	weight   float64
+}
+
+// build creates a graph from edges, adding the lone vertices first
+func build(directed bool, lone []string, edges ...edge) *Graph[string] {
+	g := NewUndirected[string]()
+	if directed {
+		g = NewDirected[string]()
+	}
+	for _, v := range lone {
+		g.AddVertex(v)
+	}
+	for _, e := range edges {
+		g.AddEdge(e.from, e.to, e.weight)
+	}
+	return g
+}
+
+func TestGraph(t *testing.T) {
+	g := build(false, []string{"x"}, edge{"a", "b", 2}, edge{"b", "c", 3}, edge{"c", "c", 1})
+	g.AddVertex("a")
+
+	if want := []string{"x", "a", "b", "c"}; !slices.Equal(g.Vertices(), want) || g.Len() != 4 {
+		t.Fatalf("Vertices = %v, want %v", g.Vertices(), want)
+	}
+	// Undirected edges are stored both ways, a self-loop once
+	if got := g.Edges("b"); len(got) != 2 || got[0] != (Edge[string]{"b", "a", 2}) || got[1] != (Edge[string]{"b", "c", 3}) {
+		t.Fatalf("Edges(b) = %v", got)
+	}
+	if got := g.Edges("c"); len(got) != 2 {
+		t.Fatalf("Edges(c) = %v, want the edge to b and the self-loop", got)
+	}
+	if g.Directed() || !g.HasVertex("x") || g.HasVertex("y") {
+		t.Fatal("Directed or HasVertex wrong")
+	}
+
+	d := build(true, nil, edge{"a", "b", 1})
+	if !d.Directed() || len(d.Edges("b")) != 0 {
+		t.Fatalf("directed edge a -> b is stored backwards too: %v", d.Edges("b"))
+	}
+}
@@ -0,0 +1,157 @@
+package graph
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+
+	"master_go_programming/56_data_structure_algorithm/data"
+)
+
+// ShortestPaths holds the result of a single-source shortest path search.
+type ShortestPaths[V comparable] struct {
+	Source V
+	dist   map[V]float64
+	prev   map[V]V
+}
+
+// DistanceTo returns the length of the shortest path from Source to v.
+// ok is false if v cannot be reached.
+func (sp *ShortestPaths[V]) DistanceTo(v V) (dist float64, ok bool) {
+	dist, ok = sp.dist[v]
+	return dist, ok
+}
+
+// PathTo returns the vertices of the shortest path from Source to v, both included.
+// ok is false if v cannot be reached.
+func (sp *ShortestPaths[V]) PathTo(v V) (path []V, ok bool) {
+	if _, ok := sp.dist[v]; !ok {
+		return nil, false
+	}
+
+	// Walk the predecessor links back to the source, then reverse
+	for {
+		path = append(path, v)
+		p, ok := sp.prev[v]
+		if !ok {
+			break
+		}
+		v = p
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path, true
+}
+
+// Dijkstra computes the shortest paths from source to every reachable vertex.
+//
+// 🔹 source: the vertex to measure distances from
+//
+// Dijkstra's algorithm repeatedly settles the closest unsettled vertex and relaxes
+// its outgoing edges. The frontier is kept in a data.PriorityQueue backed by a 4-ary heap,
+// so lowering a vertex's tentative distance is a cheap decrease-key (Update).
+//
+// Time Complexity: O((V + E) log V)
+// Space Complexity: O(V)
+//
+// ⚠️ All weights must be non-negative, otherwise ErrNegativeWeight is returned. Use BellmanFord instead.
+func (g *Graph[V]) Dijkstra(source V) (*ShortestPaths[V], error) {
+	if !g.HasVertex(source) {
+		return nil, fmt.Errorf("%w: %v", ErrVertexNotFound, source)
+	}
+	for _, v := range g.order {
+		for _, e := range g.adj[v] {
+			if e.Weight < 0 {
+				return nil, fmt.Errorf("%w: %v -> %v (%g)", ErrNegativeWeight, e.From, e.To, e.Weight)
+			}
+		}
+	}
+
+	sp := &ShortestPaths[V]{Source: source, dist: map[V]float64{source: 0}, prev: make(map[V]V)}
+	settled := make(map[V]bool)
+
+	pq := data.NewDaryPriorityQueueFunc[V](4, cmp.Compare[float64])
+	handles := map[V]*data.PQItem[V, float64]{source: pq.Push(source, 0)}
+
+	for pq.Len() > 0 {
+		v, d, _ := pq.Pop()
+		settled[v] = true
+
+		// Relax every edge leaving v
+		for _, e := range g.adj[v] {
+			if settled[e.To] {
+				continue
+			}
+			nd := d + e.Weight
+			if old, seen := sp.dist[e.To]; seen && nd >= old {
+				continue
+			}
+			sp.dist[e.To] = nd
+			sp.prev[e.To] = v
+			if h, queued := handles[e.To]; queued {
+				pq.Update(h, nd)
+			} else {
+				handles[e.To] = pq.Push(e.To, nd)
+			}
+		}
+	}
+
+	return sp, nil
+}
+
+// BellmanFord computes the shortest paths from source to every reachable vertex,
+// allowing negative edge weights.
+//
+// 🔹 source: the vertex to measure distances from
+//
+// Bellman-Ford relaxes every edge V-1 times; a shortest path never needs more edges than that.
+// If a further pass can still shorten a distance, a negative cycle is reachable
+// and ErrNegativeCycle is returned.
+//
+// Time Complexity: O(V · E)
+// Space Complexity: O(V)
+//
+// ⚠️ In an undirected graph every negative edge is itself a negative cycle.
+func (g *Graph[V]) BellmanFord(source V) (*ShortestPaths[V], error) {
+	if !g.HasVertex(source) {
+		return nil, fmt.Errorf("%w: %v", ErrVertexNotFound, source)
+	}
+
+	sp := &ShortestPaths[V]{Source: source, dist: map[V]float64{source: 0}, prev: make(map[V]V)}
+
+	// relax runs one pass over all edges and reports whether any distance changed
+	relax := func() bool {
+		changed := false
+		for _, v := range g.order {
+			d, ok := sp.dist[v]
+			if !ok {
+				continue
+			}
+			for _, e := range g.adj[v] {
+				old, seen := sp.dist[e.To]
+				if !seen {
+					old = math.Inf(1)
+				}
+				if d+e.Weight < old {
+					sp.dist[e.To] = d + e.Weight
+					sp.prev[e.To] = v
+					changed = true
+				}
+			}
+		}
+		return changed
+	}
+
+	for i := 1; i < g.Len(); i++ {
+		if !relax() {
+			// Nothing changed: the distances are final
+			return sp, nil
+		}
+	}
+
+	if relax() {
+		return nil, ErrNegativeCycle
+	}
+	return sp, nil
+}
@@ -0,0 +1,169 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// checkPath fails the test unless path runs from sp.Source to v along edges of g
+// and its weights add up to the reported distance
+func checkPath(t *testing.T, g *Graph[int], sp *ShortestPaths[int], v int) {
+	t.Helper()
+	dist, _ := sp.DistanceTo(v)
+	path, ok := sp.PathTo(v)
+	if !ok || path[0] != sp.Source || path[len(path)-1] != v {
+		t.Fatalf("PathTo(%d) = %v, %v", v, path, ok)
+	}
+	total := 0.0
+	for i := 1; i < len(path); i++ {
+		best, found := 0.0, false
+		for _, e := range g.Edges(path[i-1]) {
+			if e.To == path[i] && (!found || e.Weight < best) {
+				best, found = e.Weight, true
+			}
+		}
+		if !found {
+			t.Fatalf("path %v uses a missing edge %d -> %d", path, path[i-1], path[i])
+		}
+		total += best
+	}
+	if total != dist {
+		t.Fatalf("path %v weighs %g, but the distance is %g", path, total, dist)
+	}
+}
+
+// TestDijkstraBellmanFord compares both algorithms on random graphs with non-negative
+// integer weights, which add up exactly in float64
+func TestDijkstraBellmanFord(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for i := range 50 {
+		directed := i%2 == 0
+		n := 1 + r.IntN(30)
+		g := NewUndirected[int]()
+		if directed {
+			g = NewDirected[int]()
+		}
+		for v := range n {
+			g.AddVertex(v)
+		}
+		for range r.IntN(4 * n) {
+			g.AddEdge(r.IntN(n), r.IntN(n), float64(r.IntN(20)))
+		}
+
+		t.Run(fmt.Sprintf("%d/directed=%v/n=%d", i, directed, n), func(t *testing.T) {
+			dj, err := g.Dijkstra(0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			bf, err := g.BellmanFord(0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for v := range n {
+				d1, ok1 := dj.DistanceTo(v)
+				d2, ok2 := bf.DistanceTo(v)
+				if d1 != d2 || ok1 != ok2 {
+					t.Fatalf("distance to %d: Dijkstra %g, %v; Bellman-Ford %g, %v", v, d1, ok1, d2, ok2)
+				}
+				if ok1 {
+					checkPath(t, g, dj, v)
+					checkPath(t, g, bf, v)
+				} else if _, ok := dj.PathTo(v); ok {
+					t.Fatalf("PathTo(%d) found a path to an unreachable vertex", v)
+				}
+			}
+		})
+	}
+}
+
+func TestDijkstra(t *testing.T) {
+	// Delivery stops: the direct road from the depot to C is longer than going round by A and B
+	g := NewUndirected[string]()
+	g.AddEdge("depot", "A", 4)
+	g.AddEdge("depot", "C", 10)
+	g.AddEdge("A", "B", 2)
+	g.AddEdge("B", "C", 3)
+	g.AddEdge("C", "D", 1)
+	g.AddVertex("E")
+
+	sp, err := g.Dijkstra("depot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		to   string
+		dist float64
+		path []string
+	}{
+		{"depot", 0, []string{"depot"}},
+		{"C", 9, []string{"depot", "A", "B", "C"}},
+		{"D", 10, []string{"depot", "A", "B", "C", "D"}},
+	}
+	for _, tt := range tests {
+		d, _ := sp.DistanceTo(tt.to)
+		path, _ := sp.PathTo(tt.to)
+		if d != tt.dist || !slices.Equal(path, tt.path) {
+			t.Errorf("to %s: %g via %v, want %g via %v", tt.to, d, path, tt.dist, tt.path)
+		}
+	}
+	if _, ok := sp.DistanceTo("E"); ok {
+		t.Error("E is not connected, but has a distance")
+	}
+
+	g.AddEdge("D", "E", -1)
+	if _, err := g.Dijkstra("depot"); !errors.Is(err, ErrNegativeWeight) {
+		t.Errorf("Dijkstra with a negative edge error = %v, want ErrNegativeWeight", err)
+	}
+	if _, err := g.Dijkstra("nowhere"); !errors.Is(err, ErrVertexNotFound) {
+		t.Errorf("Dijkstra(nowhere) error = %v, want ErrVertexNotFound", err)
+	}
+}
+
+func TestBellmanFord(t *testing.T) {
+	tests := []struct {
+		name string
+		g    *Graph[string]
+		want map[string]float64 // nil when a negative cycle is expected
+	}{
+		{"negative edges", build(true, nil,
+			edge{"s", "a", 4}, edge{"s", "b", 5}, edge{"b", "a", -3}, edge{"a", "c", 2}, edge{"c", "d", -1}),
+			map[string]float64{"s": 0, "a": 2, "b": 5, "c": 4, "d": 3}},
+		{"negative cycle", build(true, nil,
+			edge{"s", "a", 1}, edge{"a", "b", 1}, edge{"b", "c", -3}, edge{"c", "a", 1}), nil},
+		{"negative self-loop", build(true, nil, edge{"s", "a", 1}, edge{"a", "a", -1}), nil},
+		// The cycle exists, but s cannot reach it
+		{"unreachable negative cycle", build(true, nil,
+			edge{"s", "a", 2}, edge{"x", "y", -5}, edge{"y", "x", 1}, edge{"x", "a", 1}),
+			map[string]float64{"s": 0, "a": 2}},
+		// Undirected, a negative edge can be walked back and forth forever
+		{"undirected negative edge", build(false, nil, edge{"s", "a", 1}, edge{"a", "b", -1}), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sp, err := tt.g.BellmanFord("s")
+			if tt.want == nil {
+				if !errors.Is(err, ErrNegativeCycle) {
+					t.Fatalf("BellmanFord error = %v, want ErrNegativeCycle", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, v := range tt.g.Vertices() {
+				d, ok := sp.DistanceTo(v)
+				want, reachable := tt.want[v]
+				if ok != reachable || d != want {
+					t.Errorf("distance to %s = %g, %v; want %g, %v", v, d, ok, want, reachable)
+				}
+			}
+		})
+	}
+
+	if _, err := NewDirected[string]().BellmanFord("s"); !errors.Is(err, ErrVertexNotFound) {
+		t.Errorf("BellmanFord on an empty graph error = %v, want ErrVertexNotFound", err)
+	}
+}
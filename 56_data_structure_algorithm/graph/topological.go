@@ -0,0 +1,89 @@
+package graph
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CycleError is returned by TopologicalSort when the graph has a cycle.
+// Cycle lists the vertices of one cycle in edge order.
+type CycleError[V comparable] struct {
+	Cycle []V
+}
+
+func (e *CycleError[V]) Error() string {
+	parts := make([]string, 0, len(e.Cycle)+1)
+	for _, v := range e.Cycle {
+		parts = append(parts, fmt.Sprint(v))
+	}
+	if len(e.Cycle) > 0 {
+		parts = append(parts, fmt.Sprint(e.Cycle[0]))
+	}
+	return "graph: cycle " + strings.Join(parts, " -> ")
+}
+
+// TopologicalSort orders the vertices of a directed graph so that every edge goes
+// from an earlier vertex to a later one, e.g. each build step after the steps it depends on
+// (add an edge from a dependency to the step that needs it).
+//
+// It runs a depth-first search and colours each vertex:
+// white (not visited), grey (on the current path) or black (finished).
+// Reaching a grey vertex again means the path loops back on itself, and a *CycleError is returned.
+//
+// Time Complexity: O(V + E)
+// Space Complexity: O(V)
+func (g *Graph[V]) TopologicalSort() ([]V, error) {
+	if !g.directed {
+		return nil, ErrUndirected
+	}
+
+	const (
+		white = iota
+		grey
+		black
+	)
+	color := make(map[V]int, g.Len())
+	var path []V     // the grey vertices, in the order they were entered
+	var finished []V // vertices in reverse topological order
+
+	var visit func(v V) error
+	visit = func(v V) error {
+		color[v] = grey
+		path = append(path, v)
+
+		for _, e := range g.adj[v] {
+			switch color[e.To] {
+			case grey:
+				// Cut the path at the first occurrence of e.To to get the cycle
+				for i, p := range path {
+					if p == e.To {
+						return &CycleError[V]{Cycle: append([]V(nil), path[i:]...)}
+					}
+				}
+			case white:
+				if err := visit(e.To); err != nil {
+					return err
+				}
+			}
+		}
+
+		path = path[:len(path)-1]
+		color[v] = black
+		finished = append(finished, v)
+		return nil
+	}
+
+	for _, v := range g.order {
+		if color[v] == white {
+			if err := visit(v); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// A vertex finishes only after everything it points to, so reverse the finish order
+	for i, j := 0, len(finished)-1; i < j; i, j = i+1, j-1 {
+		finished[i], finished[j] = finished[j], finished[i]
+	}
+	return finished, nil
+}
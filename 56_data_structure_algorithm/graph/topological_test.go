@@ -0,0 +1,72 @@
+package graph
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestTopologicalSort(t *testing.T) {
+	// Build steps: an edge goes from a dependency to the step that needs it
+	tests := []struct {
+		name  string
+		g     *Graph[string]
+		cycle bool
+	}{
+		{"empty", build(true, nil), false},
+		{"build", build(true, []string{"docs"},
+			edge{"fetch", "compile", 1}, edge{"generate", "compile", 1}, edge{"compile", "test", 1},
+			edge{"compile", "package", 1}, edge{"test", "release", 1}, edge{"package", "release", 1},
+			edge{"fetch", "generate", 1}), false},
+		{"diamond added backwards", build(true, nil,
+			edge{"c", "d", 1}, edge{"b", "d", 1}, edge{"a", "c", 1}, edge{"a", "b", 1}), false},
+		{"cycle", build(true, nil,
+			edge{"fetch", "compile", 1}, edge{"compile", "test", 1}, edge{"test", "lint", 1}, edge{"lint", "compile", 1}), true},
+		{"self-loop", build(true, nil, edge{"a", "b", 1}, edge{"b", "b", 1}), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order, err := tt.g.TopologicalSort()
+
+			if tt.cycle {
+				var ce *CycleError[string]
+				if !errors.As(err, &ce) {
+					t.Fatalf("TopologicalSort = %v, %v; want a *CycleError", order, err)
+				}
+				// The reported cycle must be made of real edges and close on itself
+				for i, v := range ce.Cycle {
+					next := ce.Cycle[(i+1)%len(ce.Cycle)]
+					if !slices.ContainsFunc(tt.g.Edges(v), func(e Edge[string]) bool { return e.To == next }) {
+						t.Fatalf("cycle %v: no edge %s -> %s", ce.Cycle, v, next)
+					}
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(order) != tt.g.Len() {
+				t.Fatalf("order %v has %d of %d vertices", order, len(order), tt.g.Len())
+			}
+			for _, v := range tt.g.Vertices() {
+				for _, e := range tt.g.Edges(v) {
+					if slices.Index(order, e.From) > slices.Index(order, e.To) {
+						t.Fatalf("order %v puts %s after %s", order, e.From, e.To)
+					}
+				}
+			}
+		})
+	}
+
+	if _, err := build(false, nil, edge{"a", "b", 1}).TopologicalSort(); !errors.Is(err, ErrUndirected) {
+		t.Fatalf("TopologicalSort of an undirected graph error = %v, want ErrUndirected", err)
+	}
+}
+
+func TestCycleError(t *testing.T) {
+	err := &CycleError[string]{Cycle: []string{"a", "b", "c"}}
+	if got, want := err.Error(), "graph: cycle a -> b -> c -> a"; got != want {
+		t.Fatalf("Error = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,80 @@
+package graph
+
+import "fmt"
+
+// BFS returns the vertices reachable from start in Breadth-First Search order.
+//
+// 🔹 start: the vertex to begin from
+//
+// BFS visits all neighbours of a vertex before going one step further,
+// so vertices come out in order of their distance (in edges) from start.
+// It uses a queue: the oldest discovered vertex is expanded first.
+//
+// Time Complexity: O(V + E)
+// Space Complexity: O(V)
+func (g *Graph[V]) BFS(start V) ([]V, error) {
+	if !g.HasVertex(start) {
+		return nil, fmt.Errorf("%w: %v", ErrVertexNotFound, start)
+	}
+
+	visited := map[V]bool{start: true}
+	queue := []V{start}
+	var order []V
+
+	for len(queue) > 0 {
+		// Dequeue the oldest vertex
+		v := queue[0]
+		queue = queue[1:]
+		order = append(order, v)
+
+		// Enqueue every neighbour we have not seen yet
+		for _, e := range g.adj[v] {
+			if !visited[e.To] {
+				visited[e.To] = true
+				queue = append(queue, e.To)
+			}
+		}
+	}
+
+	return order, nil
+}
+
+// DFS returns the vertices reachable from start in Depth-First Search (pre-)order.
+//
+// 🔹 start: the vertex to begin from
+//
+// DFS follows one path as deep as it can before backtracking.
+// It uses an explicit stack instead of recursion, so deep graphs cannot overflow the call stack.
+//
+// Time Complexity: O(V + E)
+// Space Complexity: O(V)
+func (g *Graph[V]) DFS(start V) ([]V, error) {
+	if !g.HasVertex(start) {
+		return nil, fmt.Errorf("%w: %v", ErrVertexNotFound, start)
+	}
+
+	visited := make(map[V]bool)
+	stack := []V{start}
+	var order []V
+
+	for len(stack) > 0 {
+		// Pop the newest vertex
+		v := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if visited[v] {
+			continue
+		}
+		visited[v] = true
+		order = append(order, v)
+
+		// Push neighbours in reverse so the first edge is explored first
+		edges := g.adj[v]
+		for i := len(edges) - 1; i >= 0; i-- {
+			if !visited[edges[i].To] {
+				stack = append(stack, edges[i].To)
+			}
+		}
+	}
+
+	return order, nil
+}
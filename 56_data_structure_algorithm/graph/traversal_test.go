@@ -0,0 +1,50 @@
+package graph
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestTraversal(t *testing.T) {
+	// a -> b, c; b -> d; c -> d, e; d -> f; plus g, which a cannot reach
+	dag := []edge{{"a", "b", 1}, {"a", "c", 1}, {"b", "d", 1}, {"c", "d", 1}, {"c", "e", 1}, {"d", "f", 1}, {"g", "a", 1}}
+
+	tests := []struct {
+		name     string
+		g        *Graph[string]
+		start    string
+		bfs, dfs []string
+	}{
+		{"directed", build(true, nil, dag...), "a",
+			[]string{"a", "b", "c", "d", "e", "f"},
+			[]string{"a", "b", "d", "f", "c", "e"}},
+		{"directed from a leaf", build(true, nil, dag...), "f",
+			[]string{"f"}, []string{"f"}},
+		{"undirected", build(false, nil, dag...), "d",
+			[]string{"d", "b", "c", "f", "a", "e", "g"},
+			[]string{"d", "b", "a", "c", "e", "g", "f"}},
+		{"cycle", build(true, nil, edge{"a", "b", 1}, edge{"b", "c", 1}, edge{"c", "a", 1}), "b",
+			[]string{"b", "c", "a"}, []string{"b", "c", "a"}},
+		{"lone vertex", build(true, []string{"x"}), "x",
+			[]string{"x"}, []string{"x"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := tt.g.BFS(tt.start); err != nil || !slices.Equal(got, tt.bfs) {
+				t.Errorf("BFS = %v, %v; want %v", got, err, tt.bfs)
+			}
+			if got, err := tt.g.DFS(tt.start); err != nil || !slices.Equal(got, tt.dfs) {
+				t.Errorf("DFS = %v, %v; want %v", got, err, tt.dfs)
+			}
+		})
+	}
+
+	g := build(true, nil, dag...)
+	if _, err := g.BFS("zz"); !errors.Is(err, ErrVertexNotFound) {
+		t.Errorf("BFS(zz) error = %v, want ErrVertexNotFound", err)
+	}
+	if _, err := g.DFS("zz"); !errors.Is(err, ErrVertexNotFound) {
+		t.Errorf("DFS(zz) error = %v, want ErrVertexNotFound", err)
+	}
+}
@@ -0,0 +1,78 @@
+// Package helper generates input slices for the sorting and searching examples.
+package helper
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// Distribution describes how the values of a generated slice are arranged.
+type Distribution string
+
+const (
+	Random     Distribution = "random"      // Uniformly random values in [0, n)
+	Sorted     Distribution = "sorted"      // 0, 1, 2, ..., n-1
+	Reversed   Distribution = "reversed"    // n-1, ..., 1, 0
+	FewUnique  Distribution = "few-unique"  // Random values from only 10 distinct ones
+	OrganPipe  Distribution = "organ-pipe"  // Ascending up to the middle, then descending
+	NearSorted Distribution = "near-sorted" // Sorted, with 1% of the elements swapped at random
+)
+
+// Distributions returns every supported distribution.
+func Distributions() []Distribution {
+	return []Distribution{Random, Sorted, Reversed, FewUnique, OrganPipe, NearSorted}
+}
+
+// GenerateSlice returns a slice of size random integers between -998 and 998,
+// for quick experiments like the ones in 56_data_structure_algorithm/main.go.
+func GenerateSlice(size int) []int {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	slice := make([]int, size)
+	for i := range slice {
+		slice[i] = r.Intn(999) - r.Intn(999)
+	}
+	return slice
+}
+
+// Generate returns n integers arranged according to dist, using r for the random parts.
+// The same r state always produces the same slice, which keeps benchmark runs comparable.
+func Generate(dist Distribution, n int, r *rand.Rand) ([]int, error) {
+	slice := make([]int, n)
+
+	switch dist {
+	case Random:
+		for i := range slice {
+			slice[i] = r.Intn(max(n, 1))
+		}
+	case Sorted:
+		for i := range slice {
+			slice[i] = i
+		}
+	case Reversed:
+		for i := range slice {
+			slice[i] = n - 1 - i
+		}
+	case FewUnique:
+		for i := range slice {
+			slice[i] = r.Intn(10)
+		}
+	case OrganPipe:
+		for i := range slice {
+			slice[i] = min(i, n-1-i)
+		}
+	case NearSorted:
+		for i := range slice {
+			slice[i] = i
+		}
+		for k := 0; k < n/100; k++ {
+			i, j := r.Intn(n), r.Intn(n)
+			slice[i], slice[j] = slice[j], slice[i]
+		}
+	default:
+		return nil, fmt.Errorf("unknown distribution %q", dist)
+	}
+
+	return slice, nil
+}
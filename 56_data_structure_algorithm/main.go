@@ -1,70 +1,243 @@
+// 56_data_structure_algorithm benchmarks the sorting algorithms of the data package.
+//
+// It sorts the same generated input with every selected algorithm, several times,
+// and reports the time, the allocations and the number of comparisons per sort.
+//
+// Run:
+//
+//	go run ./56_data_structure_algorithm                                    # all algorithms, 10,000 random ints
+//	go run ./56_data_structure_algorithm -algo quick,merge,std -n 1e3,1e5,1e7 -runs 3
+//	go run ./56_data_structure_algorithm -dist few-unique,organ-pipe -format csv > results.csv
+//	go run ./56_data_structure_algorithm -demo                              # the original step-by-step examples
+//
+// "std" is slices.SortFunc from the standard library, for reference.
+// radix, counting and bucket do not compare elements, so their compares column is 0.
+// The O(n²) algorithms are skipped above 200,000 elements unless they are named explicitly.
 package main
 
 import (
+	"cmp"
+	"encoding/csv"
+	"flag"
 	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"os"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"text/tabwriter"
+	"time"
+
 	"master_go_programming/56_data_structure_algorithm/data"
+	"master_go_programming/56_data_structure_algorithm/helper"
 )
 
+// quadraticLimit is the largest n at which the O(n²) sorts run when "all" algorithms are selected
+const quadraticLimit = 200_000
+
+// quadratic lists the algorithms that need O(n²) comparisons on typical input
+var quadratic = map[string]bool{"bubble": true, "selection": true, "insertion": true}
+
+// result is one row of the report
+type result struct {
+	algorithm string
+	dist      helper.Distribution
+	n         int
+	runs      int
+	nsPerOp   int64
+	allocs    uint64 // heap allocations per sort
+	bytes     uint64 // heap bytes allocated per sort
+	compares  int64  // comparisons per sort
+}
+
 func main() {
+	algos := flag.String("algo", "all", `comma-separated algorithms, or "all"`)
+	sizes := flag.String("n", "10000", "comma-separated input sizes, e.g. 1e3,1e5")
+	dists := flag.String("dist", "random", `comma-separated distributions, or "all"`)
+	runs := flag.Int("runs", 5, "timed runs per algorithm, size and distribution")
+	seed := flag.Int64("seed", 1, "seed for the generated input")
+	format := flag.String("format", "table", "table or csv")
+	demo := flag.Bool("demo", false, "run the step-by-step examples instead of the benchmark")
+	flag.Parse()
+
+	if *demo {
+		runDemo()
+		return
+	}
+
+	selected, err := selectAlgorithms(*algos)
+	if err != nil {
+		log.Fatal(err)
+	}
+	ns, err := parseSizes(*sizes)
+	if err != nil {
+		log.Fatal(err)
+	}
+	distributions, err := parseDistributions(*dists)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *runs < 1 {
+		log.Fatal("-runs must be at least 1")
+	}
+
+	var results []result
+	for _, dist := range distributions {
+		for _, n := range ns {
+			input, err := helper.Generate(dist, n, rand.New(rand.NewSource(*seed)))
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			for _, alg := range selected {
+				if quadratic[alg.Name] && n > quadraticLimit && *algos == "all" {
+					fmt.Fprintf(os.Stderr, "skipping %s at n=%d (O(n²)); name it in -algo to force\n", alg.Name, n)
+					continue
+				}
+				results = append(results, benchmark(alg, dist, input, *runs))
+			}
+		}
+	}
+
+	switch *format {
+	case "table":
+		printTable(os.Stdout, results)
+	case "csv":
+		err = writeCSV(os.Stdout, results)
+	default:
+		err = fmt.Errorf("unknown format %q", *format)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// selectAlgorithms looks up the algorithms named in list; "std" is slices.SortFunc
+func selectAlgorithms(list string) ([]data.SortAlgorithm, error) {
+	available := append(data.SortAlgorithms(), data.SortAlgorithm{
+		Name: "std",
+		Sort: slices.SortFunc[[]int, int],
+	})
+	if list == "all" {
+		return available, nil
+	}
+
+	var selected []data.SortAlgorithm
+	for _, name := range strings.Split(list, ",") {
+		i := slices.IndexFunc(available, func(a data.SortAlgorithm) bool { return a.Name == strings.TrimSpace(name) })
+		if i < 0 {
+			var names []string
+			for _, a := range available {
+				names = append(names, a.Name)
+			}
+			return nil, fmt.Errorf("unknown algorithm %q (choose from %s)", name, strings.Join(names, ", "))
+		}
+		selected = append(selected, available[i])
+	}
+	return selected, nil
+}
+
+// parseSizes accepts plain numbers and scientific notation such as 1e6
+func parseSizes(list string) ([]int, error) {
+	var ns []int
+	for _, s := range strings.Split(list, ",") {
+		f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+		if err != nil || f < 0 || f != float64(int(f)) {
+			return nil, fmt.Errorf("invalid size %q", s)
+		}
+		ns = append(ns, int(f))
+	}
+	return ns, nil
+}
+
+// parseDistributions validates the -dist flag
+func parseDistributions(list string) ([]helper.Distribution, error) {
+	if list == "all" {
+		return helper.Distributions(), nil
+	}
+
+	var dists []helper.Distribution
+	for _, s := range strings.Split(list, ",") {
+		d := helper.Distribution(strings.TrimSpace(s))
+		if !slices.Contains(helper.Distributions(), d) {
+			return nil, fmt.Errorf("unknown distribution %q (choose from %v)", s, helper.Distributions())
+		}
+		dists = append(dists, d)
+	}
+	return dists, nil
+}
+
+// benchmark sorts a fresh copy of input runs times and averages the cost of one sort.
+// Comparisons are counted in one extra run, so counting does not slow down the timed runs.
+func benchmark(alg data.SortAlgorithm, dist helper.Distribution, input []int, runs int) result {
+	res := result{algorithm: alg.Name, dist: dist, n: len(input), runs: runs}
+	work := make([]int, len(input))
+
+	var elapsed time.Duration
+	var before, after runtime.MemStats
+	var mallocs, bytes uint64
+
+	for range runs {
+		copy(work, input)
+
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+		start := time.Now()
+		alg.Sort(work, cmp.Compare[int])
+		elapsed += time.Since(start)
+		runtime.ReadMemStats(&after)
+
+		mallocs += after.Mallocs - before.Mallocs
+		bytes += after.TotalAlloc - before.TotalAlloc
+	}
+
+	if !slices.IsSorted(work) {
+		log.Fatalf("%s did not sort the %s input", alg.Name, dist)
+	}
+
+	// The counter is atomic because the parallel sorts compare from several goroutines
+	var compares atomic.Int64
+	copy(work, input)
+	alg.Sort(work, func(a, b int) int {
+		compares.Add(1)
+		return cmp.Compare(a, b)
+	})
+
+	res.nsPerOp = elapsed.Nanoseconds() / int64(runs)
+	res.allocs = mallocs / uint64(runs)
+	res.bytes = bytes / uint64(runs)
+	res.compares = compares.Load()
+	return res
+}
+
+// printTable prints the results aligned in columns
+func printTable(w io.Writer, results []result) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "algorithm\tdist\tn\truns\tns/op\tallocs/op\tB/op\tcompares\t")
+	for _, r := range results {
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%d\t%d\t%d\t%d\t\n",
+			r.algorithm, r.dist, r.n, r.runs, r.nsPerOp, r.allocs, r.bytes, r.compares)
+	}
+	tw.Flush()
+}
 
-	//Linearsearch
-	// items := []int{95, 78, 46, 58, 45, 86, 99, 251, 320}
-	// fmt.Println(data.Linearsearch(items, 78))
-
-	//Binarysearch
-	// items := []int{1, 2, 9, 20, 31, 45, 63, 70, 100}
-	// fmt.Println(data.BinarySearch(63, items))
-
-	//Interpolationsearch
-	// items := []int{1, 2, 9, 20, 31, 45, 63, 70, 100}
-	// fmt.Println(data.InterpolationSearch(items, 63))
-
-	//Bubblesort
-	// slice := helper.GenerateSlice(20)
-	// fmt.Println("\n--- Unsorted --- \n\n", slice)
-	// data.Bubblesort(slice)
-	// fmt.Println("\n--- Sorted ---\n\n", slice, "\n")
-
-	//Quicksort
-	// slice := helper.GenerateSlice(20)
-	// fmt.Println("\n--- Unsorted --- \n\n", slice)
-	// data.Quicksort(slice)
-	// fmt.Println("\n--- Sorted ---\n\n", slice, "\n")
-
-	//selectionsort
-	// slice := helper.GenerateSlice(20)
-	// fmt.Println("This is selectionsort")
-	// fmt.Println("\n--- Unsorted --- \n\n", slice)
-	// data.Selectionsort(slice)
-	// fmt.Println("\n--- Sorted ---\n\n", slice, "\n")
-
-	//combsort
-	// slice := helper.GenerateSlice(20)
-	// fmt.Println("this is combsort")
-	// fmt.Println("\n--- Unsorted --- \n\n", slice)
-	// data.Combsort(slice)
-	// fmt.Println("\n--- Sorted ---\n\n", slice, "\n")
-
-	//insertionsort
-	// slice := helper.GenerateSlice(20)
-	// fmt.Println("\n--- Unsorted --- \n\n", slice)
-	// data.Insertionsort(slice)
-	// fmt.Println("\n--- Sorted ---\n\n", slice, "\n")
-
-	list := &data.List{}
-	list.Add(1)
-	list.Add(2)
-	list.Add(3)
-	list.Add(4)
-
-	fmt.Println("Initial List: ")
-	data.PrintList(list)
-
-	list.Remove(2)
-	fmt.Println("List afteR Removing 2: ")
-	data.PrintList(list)
-
-	list.Remove(4)
-	fmt.Println("List afteR Removing 4: ")
-	data.PrintList(list)
+// writeCSV writes the results with a header row
+func writeCSV(w io.Writer, results []result) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"algorithm", "dist", "n", "runs", "ns_per_op", "allocs_per_op", "bytes_per_op", "compares"})
+	for _, r := range results {
+		cw.Write([]string{
+			r.algorithm, string(r.dist),
+			strconv.Itoa(r.n), strconv.Itoa(r.runs),
+			strconv.FormatInt(r.nsPerOp, 10),
+			strconv.FormatUint(r.allocs, 10), strconv.FormatUint(r.bytes, 10),
+			strconv.FormatInt(r.compares, 10),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
 }
@@ -0,0 +1,297 @@
+// visualizer replays the sorting algorithms of the data package step by step.
+//
+// Every compare, swap and write is recorded through data.Instrument and can be
+//   - animated in the terminal as ASCII bars (-format ascii),
+//   - exported as JSON for other tools (-format json),
+//   - exported as one SVG image per step (-format svg),
+//   - or just counted (-format counts).
+//
+// A table of operation counts per algorithm is printed at the end, which shows
+// nicely why Bubblesort is O(n²) while Quicksort is not.
+//
+// Run:
+//
+//	go run ./56_data_structure_algorithm/visualizer -algo bubble -n 15
+//	go run ./56_data_structure_algorithm/visualizer -algo all -n 200 -format counts
+//	go run ./56_data_structure_algorithm/visualizer -algo quick,merge -format json -out steps.json
+//	go run ./56_data_structure_algorithm/visualizer -algo insertion -format svg -out frames
+package main
+
+import (
+	"cmp"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"master_go_programming/56_data_structure_algorithm/data"
+)
+
+// step is one recorded event plus the slice right after it
+type step struct {
+	data.Event
+	items []int
+}
+
+// recording is everything captured while one algorithm sorted the input
+type recording struct {
+	name    string
+	initial []int
+	steps   []step
+	counts  data.OpCounts
+}
+
+func main() {
+	algos := flag.String("algo", "bubble,quick", `comma-separated algorithms, or "all"`)
+	n := flag.Int("n", 20, "number of elements to sort")
+	seed := flag.Int64("seed", 1, "seed for the random input")
+	format := flag.String("format", "ascii", "ascii, json, svg or counts")
+	out := flag.String("out", "", "output file for json (default stdout) or directory for svg (default frames)")
+	delay := flag.Duration("delay", 60*time.Millisecond, "pause between ascii frames")
+	flag.Parse()
+
+	selected, err := selectAlgorithms(*algos)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Every algorithm sorts the same input, with values 1..n so they fit as bars
+	r := rand.New(rand.NewSource(*seed))
+	input := make([]int, *n)
+	for i := range input {
+		input[i] = r.Intn(*n) + 1
+	}
+
+	var recordings []recording
+	for _, alg := range selected {
+		recordings = append(recordings, record(alg, input, *format != "counts"))
+	}
+
+	// The summary goes to stderr when stdout carries the JSON
+	summary := io.Writer(os.Stdout)
+
+	switch *format {
+	case "ascii":
+		for _, rec := range recordings {
+			animate(os.Stdout, rec, *delay)
+		}
+	case "json":
+		if *out == "" {
+			summary = os.Stderr
+		}
+		err = writeJSON(*out, recordings)
+	case "svg":
+		if *out == "" {
+			*out = "frames"
+		}
+		err = writeSVG(*out, recordings)
+	case "counts":
+	default:
+		err = fmt.Errorf("unknown format %q", *format)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	printCounts(summary, *n, recordings)
+}
+
+// selectAlgorithms looks up the algorithms named in list
+func selectAlgorithms(list string) ([]data.SortAlgorithm, error) {
+	var available []data.SortAlgorithm
+	for _, alg := range data.SortAlgorithms() {
+		if !alg.Parallel && !alg.NonComparison {
+			available = append(available, alg)
+		}
+	}
+	if list == "all" {
+		return available, nil
+	}
+
+	var selected []data.SortAlgorithm
+	for _, name := range strings.Split(list, ",") {
+		i := slices.IndexFunc(available, func(a data.SortAlgorithm) bool { return a.Name == strings.TrimSpace(name) })
+		if i < 0 {
+			var names []string
+			for _, a := range available {
+				names = append(names, a.Name)
+			}
+			return nil, fmt.Errorf("unknown algorithm %q (choose from %s)", name, strings.Join(names, ", "))
+		}
+		selected = append(selected, available[i])
+	}
+	return selected, nil
+}
+
+// record sorts a copy of input with alg, keeping every step if keepSteps is set
+func record(alg data.SortAlgorithm, input []int, keepSteps bool) recording {
+	rec := recording{name: alg.Name, initial: slices.Clone(input)}
+
+	var obs data.Observer[int]
+	if keepSteps {
+		obs = data.ObserverFunc[int](func(e data.Event, items []int) {
+			rec.steps = append(rec.steps, step{Event: e, items: slices.Clone(items)})
+		})
+	}
+
+	rec.counts = data.Instrument(slices.Clone(input), cmp.Compare[int], alg.Sort, obs)
+	return rec
+}
+
+// animate redraws the bars in the terminal after every step
+func animate(w io.Writer, rec recording, delay time.Duration) {
+	frame := func(items []int, e *data.Event, caption string) {
+		var sb strings.Builder
+		sb.WriteString("\033[H\033[2J") // move the cursor home and clear the screen
+		fmt.Fprintf(&sb, "%s sort  %s\n\n", rec.name, caption)
+
+		for i, v := range items {
+			marker := ""
+			if e != nil && (e.I == i || e.J == i) {
+				marker = " <- " + e.Kind.String()
+			}
+			fmt.Fprintf(&sb, "%4d | %s%s\n", v, strings.Repeat("█", v), marker)
+		}
+		fmt.Fprint(w, sb.String())
+		time.Sleep(delay)
+	}
+
+	frame(rec.initial, nil, "(start)")
+	var counts data.OpCounts
+	for i, s := range rec.steps {
+		countEvent(&counts, s.Kind)
+		caption := fmt.Sprintf("step %d/%d  compares: %d  swaps: %d  writes: %d",
+			i+1, len(rec.steps), counts.Compares, counts.Swaps, counts.Writes)
+		frame(s.items, &s.Event, caption)
+	}
+}
+
+// countEvent adds one event of kind to counts
+func countEvent(counts *data.OpCounts, kind data.OpKind) {
+	switch kind {
+	case data.OpCompare:
+		counts.Compares++
+	case data.OpSwap:
+		counts.Swaps++
+	case data.OpWrite:
+		counts.Writes++
+	}
+}
+
+// writeJSON exports the initial slice and the events of every recording.
+// A write event carries the value written, which is enough to replay the sort.
+func writeJSON(path string, recordings []recording) error {
+	type jsonEvent struct {
+		data.Event
+		Value *int `json:"value,omitempty"`
+	}
+	type jsonRecording struct {
+		Algorithm string        `json:"algorithm"`
+		Initial   []int         `json:"initial"`
+		Events    []jsonEvent   `json:"events"`
+		Counts    data.OpCounts `json:"counts"`
+	}
+
+	var doc []jsonRecording
+	for _, rec := range recordings {
+		jr := jsonRecording{Algorithm: rec.name, Initial: rec.initial, Counts: rec.counts}
+		for _, s := range rec.steps {
+			je := jsonEvent{Event: s.Event}
+			if s.Kind == data.OpWrite {
+				je.Value = &s.items[s.I]
+			}
+			jr.Events = append(jr.Events, je)
+		}
+		doc = append(doc, jr)
+	}
+
+	w := io.Writer(os.Stdout)
+	if path != "" {
+		f, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
+
+// writeSVG writes one SVG image per step to dir/<algorithm>/frame_00001.svg, ...
+func writeSVG(dir string, recordings []recording) error {
+	for _, rec := range recordings {
+		algDir := filepath.Join(dir, rec.name)
+		if err := os.MkdirAll(algDir, 0755); err != nil {
+			return err
+		}
+
+		frames := append([]step{{Event: data.Event{I: -1, J: -1}, items: rec.initial}}, rec.steps...)
+		for i, s := range frames {
+			file := filepath.Join(algDir, fmt.Sprintf("frame_%05d.svg", i))
+			title := fmt.Sprintf("%s sort, step %d/%d", rec.name, i, len(rec.steps))
+			if i > 0 {
+				title += ": " + s.Kind.String()
+			}
+			if err := os.WriteFile(file, []byte(renderSVG(s.items, s.Event, i > 0, title)), 0644); err != nil {
+				return err
+			}
+		}
+		fmt.Printf("Wrote %d frames to %s\n", len(frames), algDir)
+	}
+	return nil
+}
+
+// renderSVG draws items as vertical bars; the bars touched by e are coloured by kind
+func renderSVG(items []int, e data.Event, highlight bool, title string) string {
+	const barWidth, height, top = 20, 240, 30
+
+	maxValue := 1
+	for _, v := range items {
+		maxValue = max(maxValue, v)
+	}
+	width := max(len(items)*barWidth, 200)
+
+	colors := map[data.OpKind]string{
+		data.OpCompare: "#f5b700",
+		data.OpSwap:    "#e4572e",
+		data.OpWrite:   "#29335c",
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d">`+"\n", width, height+top)
+	fmt.Fprintf(&sb, `<rect width="100%%" height="100%%" fill="white"/>`+"\n")
+	fmt.Fprintf(&sb, `<text x="4" y="20" font-family="monospace" font-size="14">%s</text>`+"\n", title)
+	for i, v := range items {
+		fill := "#8d99ae"
+		if highlight && (e.I == i || e.J == i) {
+			fill = colors[e.Kind]
+		}
+		h := v * (height - 10) / maxValue
+		fmt.Fprintf(&sb, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`+"\n",
+			i*barWidth+1, top+height-h, barWidth-2, h, fill)
+	}
+	sb.WriteString("</svg>\n")
+	return sb.String()
+}
+
+// printCounts prints one row of operation counts per algorithm
+func printCounts(w io.Writer, n int, recordings []recording) {
+	fmt.Fprintf(w, "\nOperations to sort %d elements:\n", n)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "algorithm\tcompares\tswaps\twrites\t")
+	for _, rec := range recordings {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t\n", rec.name, rec.counts.Compares, rec.counts.Swaps, rec.counts.Writes)
+	}
+	tw.Flush()
+}
@@ -0,0 +1,59 @@
+package accounts
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+
+	"master_go_programming/56_data_structure_algorithm/data"
+)
+
+// Account is one record of an accounts export such as list.json
+type Account struct {
+	ID        int     `json:"id"`
+	FirstName string  `json:"firstName"`
+	LastName  string  `json:"lastName"`
+	Balance   float64 `json:"balance"`
+}
+
+// SortAccountsByBalance sorts the JSON array of accounts in inPath by balance (lowest first)
+// and writes the result to outPath.
+//
+// The file is streamed through data.ExternalSort, so it can be far larger than memory:
+// at most memoryBudget bytes of accounts are held at once, the rest waits in temporary files.
+// Accounts with the same balance keep their original order.
+func SortAccountsByBalance(inPath, outPath string, memoryBudget int64) error {
+	in, err := os.Open(inPath)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(outPath)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	byBalance := func(a, b Account) int {
+		return cmp.Compare(a.Balance, b.Balance)
+	}
+
+	err = data.ExternalSort(in, out, data.JSONArrayFormat[Account]{}, byBalance, data.ExternalSortOptions{
+		MemoryBudget: memoryBudget,
+	})
+	if err != nil {
+		return fmt.Errorf("sort %s: %w", inPath, err)
+	}
+	return out.Close()
+}
+
+// SampleSortAccounts sorts list.json by balance into list_by_balance.json
+func SampleSortAccounts() {
+	// 64 MiB per chunk; for a multi-GB export this spills a few dozen chunk files
+	if err := SortAccountsByBalance("list.json", "list_by_balance.json", 64<<20); err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	fmt.Println("Sorted accounts written to list_by_balance.json")
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"log"
+	// "master_go_programming/57_practice/accounts"
 	backtobasic "master_go_programming/57_practice/backTobasic"
 
 	"github.com/gofiber/fiber/v3"
@@ -47,6 +48,7 @@ func main() {
 	// photogallery.PhotoGallery()
 	// barcode.ExampleBarcode()
 	// SampleJson.SampleJson()
+	// accounts.SampleSortAccounts()
 
 	// beginer.SamplePanic()
 	// beginer.SampleCheckStruct()